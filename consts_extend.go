@@ -0,0 +1,28 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+// Extend cairo_extend_t
+type Extend int
+
+// Extend constants
+const (
+	ExtendNone Extend = iota
+	ExtendRepeat
+	ExtendReflect
+	ExtendPad
+)
+
+// String returns the name of the extend mode.
+func (e Extend) String() string {
+	switch e {
+	case ExtendNone:
+		return "none"
+	case ExtendRepeat:
+		return "repeat"
+	case ExtendReflect:
+		return "reflect"
+	case ExtendPad:
+		return "pad"
+	}
+	return "unknown"
+}
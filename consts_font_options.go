@@ -0,0 +1,36 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+// SubpixelOrder cairo_subpixel_order_t
+type SubpixelOrder int
+
+// SubpixelOrder constants
+const (
+	SubpixelOrderDefault SubpixelOrder = iota
+	SubpixelOrderRGB
+	SubpixelOrderBGR
+	SubpixelOrderVRGB
+	SubpixelOrderVBGR
+)
+
+// HintStyle cairo_hint_style_t
+type HintStyle int
+
+// HintStyle constants
+const (
+	HintStyleDefault HintStyle = iota
+	HintStyleNone
+	HintStyleSlight
+	HintStyleMedium
+	HintStyleFull
+)
+
+// HintMetrics cairo_hint_metrics_t
+type HintMetrics int
+
+// HintMetrics constants
+const (
+	HintMetricsDefault HintMetrics = iota
+	HintMetricsOff
+	HintMetricsOn
+)
@@ -0,0 +1,27 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+// PathDataType cairo_path_data_type_t
+type PathDataType int
+
+// PathDataType constants
+const (
+	PathMoveTo PathDataType = iota
+	PathLineTo
+	PathCurveTo
+	PathClosePath
+)
+
+func (t PathDataType) String() string {
+	switch t {
+	case PathMoveTo:
+		return "MoveTo"
+	case PathLineTo:
+		return "LineTo"
+	case PathCurveTo:
+		return "CurveTo"
+	case PathClosePath:
+		return "ClosePath"
+	}
+	return "Unknown"
+}
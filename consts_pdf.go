@@ -0,0 +1,54 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+// PDFVersion cairo_pdf_version_t
+type PDFVersion int
+
+// PDFVersion constants
+const (
+	PDFVersion14 PDFVersion = iota
+	PDFVersion15
+	PDFVersion16
+	PDFVersion17
+)
+
+// PDFOutlineFlags cairo_pdf_outline_flags_t
+type PDFOutlineFlags int
+
+// PDFOutlineFlags constants, which can be combined with |.
+const (
+	// PDFOutlineFlagOpen shows the outline item's children expanded.
+	PDFOutlineFlagOpen PDFOutlineFlags = 1 << iota
+	// PDFOutlineFlagBold shows the outline item in bold.
+	PDFOutlineFlagBold
+	// PDFOutlineFlagItalic shows the outline item in italics.
+	PDFOutlineFlagItalic
+)
+
+// PDFOutlineRoot is the parent id of top level outline items.
+const PDFOutlineRoot = 0
+
+// PDFMetadata cairo_pdf_metadata_t
+type PDFMetadata int
+
+// PDFMetadata constants
+const (
+	PDFMetadataTitle PDFMetadata = iota
+	PDFMetadataAuthor
+	PDFMetadataSubject
+	PDFMetadataKeywords
+	PDFMetadataCreator
+	// PDFMetadataCreateDate is a date in ISO-8601 format, such as "2024-03-01T12:00:00Z".
+	PDFMetadataCreateDate
+	// PDFMetadataModDate is a date in ISO-8601 format, such as "2024-03-01T12:00:00Z".
+	PDFMetadataModDate
+)
+
+// Tag names for Context.TagBegin and TagEnd.
+const (
+	// TagLink makes the drawing between TagBegin and TagEnd a link.
+	// Attributes are uri='...' for an external link, or dest='...' or page=n for an internal one.
+	TagLink = "Link"
+	// TagDest marks a destination for internal links. Attributes are name='...' and optionally x=n y=n.
+	TagDest = "cairo.dest"
+)
@@ -0,0 +1,11 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+// PSLevel cairo_ps_level_t
+type PSLevel int
+
+// PSLevel constants
+const (
+	PSLevel2 PSLevel = iota
+	PSLevel3
+)
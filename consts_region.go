@@ -0,0 +1,28 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+// RegionOverlap cairo_region_overlap_t
+type RegionOverlap int
+
+// RegionOverlap constants
+const (
+	// RegionOverlapIn means the rectangle is entirely inside the region.
+	RegionOverlapIn RegionOverlap = iota
+	// RegionOverlapOut means the rectangle is entirely outside the region.
+	RegionOverlapOut
+	// RegionOverlapPart means the rectangle is partly inside and partly outside the region.
+	RegionOverlapPart
+)
+
+// String returns the name of the overlap.
+func (o RegionOverlap) String() string {
+	switch o {
+	case RegionOverlapIn:
+		return "in"
+	case RegionOverlapOut:
+		return "out"
+	case RegionOverlapPart:
+		return "part"
+	}
+	return "unknown"
+}
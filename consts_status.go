@@ -16,6 +16,12 @@ func (s Status) String() string {
 	return C.GoString(C.cairo_status_to_string(C.cairo_status_t(s)))
 }
 
+// Error makes a Status usable as an error. Errors from failed cairo operations are Status values,
+// so they can be matched with errors.Is, for example errors.Is(err, cairo.StatusNoMemory).
+func (s Status) Error() string {
+	return s.String()
+}
+
 // Status constants
 const (
 	StatusSuccess Status = iota
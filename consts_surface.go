@@ -0,0 +1,34 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+// SurfaceType cairo_surface_type_t
+type SurfaceType int
+
+// SurfaceType constants
+const (
+	SurfaceTypeImage SurfaceType = iota
+	SurfaceTypePDF
+	SurfaceTypePS
+	SurfaceTypeXlib
+	SurfaceTypeXCB
+	SurfaceTypeGlitz
+	SurfaceTypeQuartz
+	SurfaceTypeWin32
+	SurfaceTypeBeOS
+	SurfaceTypeDirectFB
+	SurfaceTypeSVG
+	SurfaceTypeOS2
+	SurfaceTypeWin32Printing
+	SurfaceTypeQuartzImage
+	SurfaceTypeScript
+	SurfaceTypeQt
+	SurfaceTypeRecording
+	SurfaceTypeVG
+	SurfaceTypeGL
+	SurfaceTypeDRM
+	SurfaceTypeTee
+	SurfaceTypeXML
+	SurfaceTypeSkia
+	SurfaceTypeSubsurface
+	SurfaceTypeCOGL
+)
@@ -0,0 +1,28 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+// SVGVersion cairo_svg_version_t
+type SVGVersion int
+
+// SVGVersion constants
+const (
+	SVGVersion11 SVGVersion = iota
+	SVGVersion12
+)
+
+// SVGUnit cairo_svg_unit_t
+type SVGUnit int
+
+// SVGUnit constants
+const (
+	SVGUnitUser SVGUnit = iota
+	SVGUnitEm
+	SVGUnitEx
+	SVGUnitPx
+	SVGUnitIn
+	SVGUnitCm
+	SVGUnitMm
+	SVGUnitPt
+	SVGUnitPc
+	SVGUnitPercent
+)
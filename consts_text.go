@@ -23,3 +23,54 @@ type TextExtents struct {
 	Xadvance float64
 	Yadvance float64
 }
+
+// TextAlign determines how text is aligned.
+type TextAlign int
+
+// TextAlign constants
+const (
+	TextAlignLeft TextAlign = iota
+	TextAlignCenter
+	TextAlignRight
+	TextAlignJustify
+)
+
+// VerticalAlign determines how a block of text is aligned vertically.
+type VerticalAlign int
+
+// VerticalAlign constants
+const (
+	VerticalAlignTop VerticalAlign = iota
+	VerticalAlignMiddle
+	VerticalAlignBottom
+)
+
+// FontExtents cairo_font_extents_t
+type FontExtents struct {
+	Ascent      float64
+	Descent     float64
+	Height      float64
+	MaxXadvance float64
+	MaxYadvance float64
+}
+
+// Glyph cairo_glyph_t
+// Index is the glyph index in the font, X, Y is the position of the glyph's origin.
+type Glyph struct {
+	Index uint64
+	X     float64
+	Y     float64
+}
+
+// FontType cairo_font_type_t
+type FontType int
+
+// FontType constants
+const (
+	FontTypeToy FontType = iota
+	FontTypeFT
+	FontTypeWin32
+	FontTypeQuartz
+	FontTypeUser
+	FontTypeDWrite
+)
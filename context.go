@@ -9,7 +9,7 @@ package cairo
 import "C"
 
 import (
-	"errors"
+	"runtime"
 	"unsafe"
 
 	"github.com/bit101/bitlib/geom"
@@ -20,16 +20,17 @@ type Context struct {
 	context       *C.cairo_t
 	Surface       *Surface
 	Width, Height float64
+	// whether this value owns a reference, released by Destroy.
+	owned bool
+	// shapes records filled and stroked paths while shapeID is set. See BeginShape.
+	shapes  *ShapeRegistry
+	shapeID string
 }
 
 // NewContext creates a new cairo context.
 func NewContext(surface *Surface) *Context {
-	context := &Context{
-		C.cairo_create(surface.surface),
-		surface,
-		float64(surface.GetWidth()),
-		float64(surface.GetHeight()),
-	}
+	defer runtime.KeepAlive(surface)
+	context := newContext(C.cairo_create(surface.surface), surface, surface.GetWidthF(), surface.GetHeightF())
 	context.SetLineWidth(0.5)
 	// removed the following line because it ruined surfaces created from a png.
 	// not sure if it will break something else later.
@@ -45,6 +46,7 @@ type Rectangle struct {
 
 // GetCurrentPoint gets the current drawing point.
 func (c *Context) GetCurrentPoint() (float64, float64) {
+	defer runtime.KeepAlive(c)
 	if !c.HasCurrentPoint() {
 		return 0, 0
 	}
@@ -60,57 +62,71 @@ func (c *Context) GetCurrentPoint() (float64, float64) {
 
 // HasCurrentPoint returns whether or not there is a current drawing point.
 func (c *Context) HasCurrentPoint() bool {
+	defer runtime.KeepAlive(c)
 	return C.cairo_has_current_point(c.context) != 0
 }
 
 // Save saves the current state of the context.
 func (c *Context) Save() {
+	defer runtime.KeepAlive(c)
 	C.cairo_save(c.context)
 }
 
 // Restore restores the the last saved state of the context.
 func (c *Context) Restore() {
+	defer runtime.KeepAlive(c)
 	C.cairo_restore(c.context)
 }
 
 // PushGroup temporarily redirects drawing to an intermediate context known as a group.
 func (c *Context) PushGroup() {
+	defer runtime.KeepAlive(c)
 	C.cairo_push_group(c.context)
 }
 
 // GetGroupTarget gets the surface for the current target - used to get the surface of a group after pushing.
+// The surface belongs to the context and does not need to be destroyed.
 func (c *Context) GetGroupTarget() *Surface {
-	return &Surface{C.cairo_get_group_target(c.context)}
+	defer runtime.KeepAlive(c)
+	return &Surface{surface: C.cairo_get_group_target(c.context), owner: c}
 }
 
 // PushGroupWithContent temporarily redirects drawing to an intermediate context known as a group, with content.
 func (c *Context) PushGroupWithContent(content Content) {
+	defer runtime.KeepAlive(c)
 	C.cairo_push_group_with_content(c.context, C.cairo_content_t(content))
 }
 
 // PopGroup terminates the redirection begun by a call to cairo_push_group() or cairo_push_group_with_content() and returns a new pattern containing the results of all drawing operations performed to the group.
 func (c *Context) PopGroup() (pattern *Pattern) {
-	return &Pattern{C.cairo_pop_group(c.context)}
+	defer runtime.KeepAlive(c)
+	return newPattern(C.cairo_pop_group(c.context))
 }
 
 // PopGroupToSource terminates the redirection begun by a call to cairo_push_group() or cairo_push_group_with_content() and installs the resulting pattern as the source pattern in the given cairo context.
 func (c *Context) PopGroupToSource() {
+	defer runtime.KeepAlive(c)
 	C.cairo_pop_group_to_source(c.context)
 }
 
 // SetOperator sets the compositing operator to be used for all drawing operations.
 func (c *Context) SetOperator(operator Operator) {
+	defer runtime.KeepAlive(c)
 	C.cairo_set_operator(c.context, C.cairo_operator_t(operator))
 }
 
 // SetSource sets the pattern to draw with.
 func (c *Context) SetSource(pattern *Pattern) {
+	defer runtime.KeepAlive(c)
+	defer runtime.KeepAlive(pattern)
 	C.cairo_set_source(c.context, pattern.pattern)
 }
 
 // GetSource gets the current pattern being used.
+// The pattern belongs to the context and does not need to be destroyed.
 func (c *Context) GetSource() *Pattern {
-	return &Pattern{C.cairo_get_source(c.context)}
+	defer runtime.KeepAlive(c)
+	return &Pattern{pattern: C.cairo_get_source(c.context), owner: c}
 }
 
 // GetSourceRGB gets the r, g, b values of the current source pattern.
@@ -121,67 +137,111 @@ func (c *Context) GetSourceRGB() (float64, float64, float64) {
 
 // GetSourceRGBA gets the r, g, b, a values of the current source pattern.
 func (c *Context) GetSourceRGBA() (float64, float64, float64, float64) {
-	pattern := &Pattern{C.cairo_get_source(c.context)}
+	defer runtime.KeepAlive(c)
+	pattern := &Pattern{pattern: C.cairo_get_source(c.context)}
 	return pattern.GetRGBA()
 }
 
 // SetSourceRGB sets the r, g, b values to draw with.
 func (c *Context) SetSourceRGB(red, green, blue float64) {
+	defer runtime.KeepAlive(c)
 	C.cairo_set_source_rgb(c.context, C.double(red), C.double(green), C.double(blue))
 }
 
 // SetSourceRGBA sets the r, g, b, a values to draw with.
 func (c *Context) SetSourceRGBA(red, green, blue, alpha float64) {
+	defer runtime.KeepAlive(c)
 	C.cairo_set_source_rgba(c.context, C.double(red), C.double(green), C.double(blue), C.double(alpha))
 }
 
 // SetSourceSurface is a convenience function for creating a pattern from surface and setting it as the source in cr with cairo_set_source().
 func (c *Context) SetSourceSurface(surface *Surface, x, y float64) {
+	defer runtime.KeepAlive(c)
+	defer runtime.KeepAlive(surface)
 	C.cairo_set_source_surface(c.context, surface.surface, C.double(x), C.double(y))
 }
 
 // SetTolerance sets the tolerance used when converting paths into trapezoids.
 func (c *Context) SetTolerance(tolerance float64) {
+	defer runtime.KeepAlive(c)
 	C.cairo_set_tolerance(c.context, C.double(tolerance))
 }
 
 // SetAntialias sets the antialias value to use.
 func (c *Context) SetAntialias(antialias Antialias) {
+	defer runtime.KeepAlive(c)
 	C.cairo_set_antialias(c.context, C.cairo_antialias_t(antialias))
 }
 
 // SetFillRule sets the current fill rule within the cairo context.
 func (c *Context) SetFillRule(fillRule FillRule) {
+	defer runtime.KeepAlive(c)
 	C.cairo_set_fill_rule(c.context, C.cairo_fill_rule_t(fillRule))
 }
 
+// GetFillRule gets the current fill rule within the cairo context.
+func (c *Context) GetFillRule() FillRule {
+	defer runtime.KeepAlive(c)
+	return FillRule(C.cairo_get_fill_rule(c.context))
+}
+
 // SetLineWidth sets the pixel width that will be used when drawing lines.
 func (c *Context) SetLineWidth(width float64) {
+	defer runtime.KeepAlive(c)
 	C.cairo_set_line_width(c.context, C.double(width))
 }
 
 // GetLineWidth sets the pixel width that will be used when drawing lines.
 func (c *Context) GetLineWidth() float64 {
+	defer runtime.KeepAlive(c)
 	w := C.cairo_get_line_width(c.context)
 	return float64(w)
 }
 
 // SetLineCap sets the form of line cap used when drawing lines.
 func (c *Context) SetLineCap(lineCap LineCap) {
+	defer runtime.KeepAlive(c)
 	C.cairo_set_line_cap(c.context, C.cairo_line_cap_t(lineCap))
 }
 
+// GetLineCap gets the form of line cap used when drawing lines.
+func (c *Context) GetLineCap() LineCap {
+	defer runtime.KeepAlive(c)
+	return LineCap(C.cairo_get_line_cap(c.context))
+}
+
 // SetLineJoin sets the type of join to use where two line segments connect.
 func (c *Context) SetLineJoin(lineJoin LineJoin) {
+	defer runtime.KeepAlive(c)
 	C.cairo_set_line_join(c.context, C.cairo_line_join_t(lineJoin))
 }
 
+// GetLineJoin gets the type of join to use where two line segments connect.
+func (c *Context) GetLineJoin() LineJoin {
+	defer runtime.KeepAlive(c)
+	return LineJoin(C.cairo_get_line_join(c.context))
+}
+
 // SetDash sets the dash pattern to be used when drawing lines.
 func (c *Context) SetDash(dashes []float64, numDashes int, offset float64) {
+	defer runtime.KeepAlive(c)
 	dashesp := (*C.double)(&dashes[0])
 	C.cairo_set_dash(c.context, dashesp, C.int(numDashes), C.double(offset))
 }
 
+// GetDash gets the current dash pattern and offset. The pattern is empty if dashing is disabled.
+func (c *Context) GetDash() ([]float64, float64) {
+	defer runtime.KeepAlive(c)
+	dashes := make([]float64, int(C.cairo_get_dash_count(c.context)))
+	offset := 0.0
+	if len(dashes) == 0 {
+		C.cairo_get_dash(c.context, nil, (*C.double)(&offset))
+		return dashes, offset
+	}
+	C.cairo_get_dash(c.context, (*C.double)(&dashes[0]), (*C.double)(&offset))
+	return dashes, offset
+}
+
 // SimpleDash sets the dash pattern to be used when drawing lines.
 func (c *Context) SimpleDash(on, off float64) {
 	dashes := []float64{on, off}
@@ -195,59 +255,84 @@ func (c *Context) DisableDash() {
 
 // SetMiterLimit sets the sharpness of the corner in line joins.
 func (c *Context) SetMiterLimit(limit float64) {
+	defer runtime.KeepAlive(c)
 	C.cairo_set_miter_limit(c.context, C.double(limit))
 }
 
+// GetMiterLimit gets the sharpness of the corner in line joins.
+func (c *Context) GetMiterLimit() float64 {
+	defer runtime.KeepAlive(c)
+	return float64(C.cairo_get_miter_limit(c.context))
+}
+
 // Translate translates the context by the specified amounts.
 func (c *Context) Translate(tx, ty float64) {
+	defer runtime.KeepAlive(c)
 	C.cairo_translate(c.context, C.double(tx), C.double(ty))
 }
 
 // Scale scales the context by the specified amount.
 func (c *Context) Scale(sx, sy float64) {
+	defer runtime.KeepAlive(c)
 	C.cairo_scale(c.context, C.double(sx), C.double(sy))
 }
 
 // Rotate rotates the context by the specified amount.
 func (c *Context) Rotate(angle float64) {
+	defer runtime.KeepAlive(c)
 	C.cairo_rotate(c.context, C.double(angle))
 }
 
 // Transform transforms the context with the specified matrix.
 func (c *Context) Transform(matrix Matrix) {
+	defer runtime.KeepAlive(c)
 	C.cairo_transform(c.context, matrix.Native())
 }
 
 // SetMatrix resets the context transform to the specified matrix
 func (c *Context) SetMatrix(matrix Matrix) {
+	defer runtime.KeepAlive(c)
 	C.cairo_set_matrix(c.context, matrix.Native())
 }
 
+// GetMatrix returns the current transform of the context.
+func (c *Context) GetMatrix() Matrix {
+	defer runtime.KeepAlive(c)
+	var matrix Matrix
+	C.cairo_get_matrix(c.context, matrix.Native())
+	return matrix
+}
+
 // IdentityMatrix sets the transformation matrix for the context to an identity matrix.
 func (c *Context) IdentityMatrix() {
+	defer runtime.KeepAlive(c)
 	C.cairo_identity_matrix(c.context)
 }
 
 // UserToDevice tbd
 func (c *Context) UserToDevice(x, y float64) (float64, float64) {
+	defer runtime.KeepAlive(c)
 	C.cairo_user_to_device(c.context, (*C.double)(&x), (*C.double)(&y))
 	return x, y
 }
 
 // UserToDeviceDistance tbd
 func (c *Context) UserToDeviceDistance(dx, dy float64) (float64, float64) {
+	defer runtime.KeepAlive(c)
 	C.cairo_user_to_device_distance(c.context, (*C.double)(&dx), (*C.double)(&dy))
 	return dx, dy
 }
 
 // DeviceToUser tbd
 func (c *Context) DeviceToUser(x, y float64) (float64, float64) {
+	defer runtime.KeepAlive(c)
 	C.cairo_device_to_user(c.context, (*C.double)(&x), (*C.double)(&y))
 	return x, y
 }
 
 // DeviceToUserDistance tbd
 func (c *Context) DeviceToUserDistance(x, y float64) (float64, float64) {
+	defer runtime.KeepAlive(c)
 	C.cairo_device_to_user_distance(c.context, (*C.double)(&x), (*C.double)(&y))
 	return x, y
 }
@@ -256,11 +341,13 @@ func (c *Context) DeviceToUserDistance(x, y float64) (float64, float64) {
 
 // NewPath begins a new drawing path.
 func (c *Context) NewPath() {
+	defer runtime.KeepAlive(c)
 	C.cairo_new_path(c.context)
 }
 
 // MoveTo moves to the specified point.
 func (c *Context) MoveTo(x, y float64) {
+	defer runtime.KeepAlive(c)
 	C.cairo_move_to(c.context, C.double(x), C.double(y))
 }
 
@@ -271,11 +358,13 @@ func (c *Context) MoveToPoint(p *geom.Point) {
 
 // NewSubPath creates a new sub drawing path.
 func (c *Context) NewSubPath() {
+	defer runtime.KeepAlive(c)
 	C.cairo_new_sub_path(c.context)
 }
 
 // LineTo draws a line to the specified point.
 func (c *Context) LineTo(x, y float64) {
+	defer runtime.KeepAlive(c)
 	C.cairo_line_to(c.context, C.double(x), C.double(y))
 }
 
@@ -286,6 +375,7 @@ func (c *Context) LineToPoint(p *geom.Point) {
 
 // CurveTo draws a Bezier curve through the specified points.
 func (c *Context) CurveTo(x1, y1, x2, y2, x3, y3 float64) {
+	defer runtime.KeepAlive(c)
 	C.cairo_curve_to(c.context,
 		C.double(x1), C.double(y1),
 		C.double(x2), C.double(y2),
@@ -294,6 +384,7 @@ func (c *Context) CurveTo(x1, y1, x2, y2, x3, y3 float64) {
 
 // Arc draws and arc with the specified parameters.
 func (c *Context) Arc(xc, yc, radius, angle1, angle2 float64, antiClockwise bool) {
+	defer runtime.KeepAlive(c)
 	if antiClockwise {
 		C.cairo_arc_negative(c.context,
 			C.double(xc), C.double(yc),
@@ -311,16 +402,19 @@ func (c *Context) Arc(xc, yc, radius, angle1, angle2 float64, antiClockwise bool
 
 // RelMoveTo moves to coordinates relative to the current point.
 func (c *Context) RelMoveTo(dx, dy float64) {
+	defer runtime.KeepAlive(c)
 	C.cairo_rel_move_to(c.context, C.double(dx), C.double(dy))
 }
 
 // RelLineTo draws a line to coordinates relative to the current point.
 func (c *Context) RelLineTo(dx, dy float64) {
+	defer runtime.KeepAlive(c)
 	C.cairo_rel_line_to(c.context, C.double(dx), C.double(dy))
 }
 
 // RelCurveTo draws a curve to coords relative the the current point.
 func (c *Context) RelCurveTo(dx1, dy1, dx2, dy2, dx3, dy3 float64) {
+	defer runtime.KeepAlive(c)
 	C.cairo_rel_curve_to(c.context,
 		C.double(dx1), C.double(dy1),
 		C.double(dx2), C.double(dy2),
@@ -329,6 +423,7 @@ func (c *Context) RelCurveTo(dx1, dy1, dx2, dy2, dx3, dy3 float64) {
 
 // Rectangle creates a rectangle path.
 func (c *Context) Rectangle(x, y, width, height float64) {
+	defer runtime.KeepAlive(c)
 	C.cairo_rectangle(c.context,
 		C.double(x), C.double(y),
 		C.double(width), C.double(height))
@@ -336,11 +431,13 @@ func (c *Context) Rectangle(x, y, width, height float64) {
 
 // ClosePath closes the current path, drawing a line back to the starting point.
 func (c *Context) ClosePath() {
+	defer runtime.KeepAlive(c)
 	C.cairo_close_path(c.context)
 }
 
 // PathExtents tbd
 func (c *Context) PathExtents() (left, top, right, bottom float64) {
+	defer runtime.KeepAlive(c)
 	C.cairo_path_extents(c.context,
 		(*C.double)(&left), (*C.double)(&top),
 		(*C.double)(&right), (*C.double)(&bottom))
@@ -352,51 +449,66 @@ func (c *Context) PathExtents() (left, top, right, bottom float64) {
 
 // Paint paints the canvas with the current source color.
 func (c *Context) Paint() {
+	defer runtime.KeepAlive(c)
 	C.cairo_paint(c.context)
 }
 
 // PaintWithAlpha tbd
 func (c *Context) PaintWithAlpha(alpha float64) {
+	defer runtime.KeepAlive(c)
 	C.cairo_paint_with_alpha(c.context, C.double(alpha))
 }
 
 // Mask tbd
 func (c *Context) Mask(pattern Pattern) {
+	defer runtime.KeepAlive(c)
 	C.cairo_mask(c.context, pattern.pattern)
 }
 
 // MaskSurface tbd
 func (c *Context) MaskSurface(surface *Surface, surfaceX, surfaceY float64) {
+	defer runtime.KeepAlive(c)
+	defer runtime.KeepAlive(surface)
 	C.cairo_mask_surface(c.context, surface.surface, C.double(surfaceX), C.double(surfaceY))
 }
 
 // Stroke strokes the current path and clears the path.
 func (c *Context) Stroke() {
+	defer runtime.KeepAlive(c)
+	c.recordShape(false)
 	C.cairo_stroke(c.context)
 }
 
 // StrokePreserve stokes the current path but does not clear it.
 func (c *Context) StrokePreserve() {
+	defer runtime.KeepAlive(c)
+	c.recordShape(false)
 	C.cairo_stroke_preserve(c.context)
 }
 
 // Fill fills the current path and clears the path.
 func (c *Context) Fill() {
+	defer runtime.KeepAlive(c)
+	c.recordShape(true)
 	C.cairo_fill(c.context)
 }
 
 // FillPreserve fills the current path but does not clear it.
 func (c *Context) FillPreserve() {
+	defer runtime.KeepAlive(c)
+	c.recordShape(true)
 	C.cairo_fill_preserve(c.context)
 }
 
 // CopyPage tbd
 func (c *Context) CopyPage() {
+	defer runtime.KeepAlive(c)
 	C.cairo_copy_page(c.context)
 }
 
 // ShowPage tbd
 func (c *Context) ShowPage() {
+	defer runtime.KeepAlive(c)
 	C.cairo_show_page(c.context)
 }
 
@@ -405,11 +517,13 @@ func (c *Context) ShowPage() {
 
 // InStroke tbd
 func (c *Context) InStroke(x, y float64) bool {
+	defer runtime.KeepAlive(c)
 	return C.cairo_in_stroke(c.context, C.double(x), C.double(y)) != 0
 }
 
 // InFill tbd
 func (c *Context) InFill(x, y float64) bool {
+	defer runtime.KeepAlive(c)
 	return C.cairo_in_fill(c.context, C.double(x), C.double(y)) != 0
 }
 
@@ -418,6 +532,7 @@ func (c *Context) InFill(x, y float64) bool {
 
 // StrokeExtents tbd
 func (c *Context) StrokeExtents() (left, top, right, bottom float64) {
+	defer runtime.KeepAlive(c)
 	C.cairo_stroke_extents(c.context,
 		(*C.double)(&left), (*C.double)(&top),
 		(*C.double)(&right), (*C.double)(&bottom))
@@ -426,6 +541,7 @@ func (c *Context) StrokeExtents() (left, top, right, bottom float64) {
 
 // FillExtents tbd
 func (c *Context) FillExtents() (left, top, right, bottom float64) {
+	defer runtime.KeepAlive(c)
 	C.cairo_fill_extents(c.context,
 		(*C.double)(&left), (*C.double)(&top),
 		(*C.double)(&right), (*C.double)(&bottom))
@@ -437,36 +553,45 @@ func (c *Context) FillExtents() (left, top, right, bottom float64) {
 
 // ResetClip tbd
 func (c *Context) ResetClip() {
+	defer runtime.KeepAlive(c)
 	C.cairo_reset_clip(c.context)
 }
 
 // Clip tbd
 func (c *Context) Clip() {
+	defer runtime.KeepAlive(c)
 	C.cairo_clip(c.context)
 }
 
 // ClipPreserve tbd
 func (c *Context) ClipPreserve() {
+	defer runtime.KeepAlive(c)
 	C.cairo_clip_preserve(c.context)
 }
 
 // ClipExtents tbd
 func (c *Context) ClipExtents() (left, top, right, bottom float64) {
+	defer runtime.KeepAlive(c)
 	C.cairo_clip_extents(c.context,
 		(*C.double)(&left), (*C.double)(&top),
 		(*C.double)(&right), (*C.double)(&bottom))
 	return left, top, right, bottom
 }
 
-// ClipRectangleList tbd
+// ClipRectangleList returns the current clip region as a list of rectangles in user space.
+// It returns StatusClipNotRepresentable if the clip is not made up of rectangles in user space.
 func (c *Context) ClipRectangleList() ([]Rectangle, error) {
+	defer runtime.KeepAlive(c)
 	list := C.cairo_copy_clip_rectangle_list(c.context)
 	defer C.cairo_rectangle_list_destroy(list)
-	rects := make([]Rectangle, int(list.num_rectangles))
-	C.memcpy(unsafe.Pointer(&rects[0]), unsafe.Pointer(list.rectangles), C.size_t(list.num_rectangles*8))
 	status := Status(list.status)
 	if status != StatusSuccess {
-		return nil, errors.New(status.String())
+		return nil, status
+	}
+	native := unsafe.Slice(list.rectangles, int(list.num_rectangles))
+	rects := make([]Rectangle, len(native))
+	for i, rect := range native {
+		rects[i] = Rectangle{float64(rect.x), float64(rect.y), float64(rect.width), float64(rect.height)}
 	}
 	return rects, nil
 }
@@ -475,7 +600,13 @@ func (c *Context) ClipRectangleList() ([]Rectangle, error) {
 // Font/Text methods
 
 // SelectFontFace selectes the active font with slant and weight.
+// Font faces registered with RegisterUserFontFace take precedence over system fonts.
 func (c *Context) SelectFontFace(name string, fontSlant, fontWeight int) {
+	defer runtime.KeepAlive(c)
+	if face := getUserFontFace(name, fontSlant, fontWeight); face != nil {
+		c.SetFontFace(face)
+		return
+	}
 	str := C.CString(name)
 	C.cairo_select_font_face(c.context, str, C.cairo_font_slant_t(fontSlant), C.cairo_font_weight_t(fontWeight))
 	C.free(unsafe.Pointer(str))
@@ -483,16 +614,19 @@ func (c *Context) SelectFontFace(name string, fontSlant, fontWeight int) {
 
 // SetFontSize sets the size of the current font.
 func (c *Context) SetFontSize(size float64) {
+	defer runtime.KeepAlive(c)
 	C.cairo_set_font_size(c.context, C.double(size))
 }
 
 // SetFontMatrix tbd
 func (c *Context) SetFontMatrix(matrix Matrix) {
+	defer runtime.KeepAlive(c)
 	C.cairo_set_font_matrix(c.context, matrix.Native())
 }
 
 // ShowText draws and fills the given text at the current drawing location.
 func (c *Context) ShowText(text string) {
+	defer runtime.KeepAlive(c)
 	cs := C.CString(text)
 	C.cairo_show_text(c.context, cs)
 	C.free(unsafe.Pointer(cs))
@@ -500,6 +634,7 @@ func (c *Context) ShowText(text string) {
 
 // TextPath tbd
 func (c *Context) TextPath(text string) {
+	defer runtime.KeepAlive(c)
 	cs := C.CString(text)
 	C.cairo_text_path(c.context, cs)
 	C.free(unsafe.Pointer(cs))
@@ -507,27 +642,117 @@ func (c *Context) TextPath(text string) {
 
 // TextExtents tbd
 func (c *Context) TextExtents(text string) *TextExtents {
+	defer runtime.KeepAlive(c)
 	cte := C.cairo_text_extents_t{}
 	cs := C.CString(text)
 	C.cairo_text_extents(c.context, cs, &cte)
 	C.free(unsafe.Pointer(cs))
-	te := &TextExtents{
-		Xbearing: float64(cte.x_bearing),
-		Ybearing: float64(cte.y_bearing),
-		Width:    float64(cte.width),
-		Height:   float64(cte.height),
-		Xadvance: float64(cte.x_advance),
-		Yadvance: float64(cte.y_advance),
-	}
-	return te
+	return newTextExtents(cte)
+}
+
+// FontExtents returns the extents of the current font.
+func (c *Context) FontExtents() *FontExtents {
+	defer runtime.KeepAlive(c)
+	cfe := C.cairo_font_extents_t{}
+	C.cairo_font_extents(c.context, &cfe)
+	return newFontExtents(cfe)
+}
+
+// ShowGlyphs draws and fills the given glyphs, each at its own position.
+func (c *Context) ShowGlyphs(glyphs []Glyph) {
+	defer runtime.KeepAlive(c)
+	cglyphs := glyphsToNative(glyphs)
+	C.cairo_show_glyphs(c.context, glyphsPtr(cglyphs), C.int(len(cglyphs)))
+}
+
+// GlyphPath adds the outlines of the given glyphs to the current path.
+func (c *Context) GlyphPath(glyphs []Glyph) {
+	defer runtime.KeepAlive(c)
+	cglyphs := glyphsToNative(glyphs)
+	C.cairo_glyph_path(c.context, glyphsPtr(cglyphs), C.int(len(cglyphs)))
+}
+
+// GlyphExtents returns the extents of the given glyphs in the current font.
+func (c *Context) GlyphExtents(glyphs []Glyph) *TextExtents {
+	defer runtime.KeepAlive(c)
+	cte := C.cairo_text_extents_t{}
+	cglyphs := glyphsToNative(glyphs)
+	C.cairo_glyph_extents(c.context, glyphsPtr(cglyphs), C.int(len(cglyphs)), &cte)
+	return newTextExtents(cte)
+}
+
+// TextToGlyphs converts text to a slice of glyphs in the current font, starting at x, y.
+func (c *Context) TextToGlyphs(x, y float64, text string) ([]Glyph, error) {
+	scaledFont := c.GetScaledFont()
+	defer scaledFont.Destroy()
+	return scaledFont.TextToGlyphs(x, y, text)
+}
+
+// SetFontFace sets the current font face, replacing any font selected with SelectFontFace.
+func (c *Context) SetFontFace(fontFace *FontFace) {
+	defer runtime.KeepAlive(c)
+	C.cairo_set_font_face(c.context, fontFace.fontFace)
+}
+
+// GetFontFace returns the current font face. Call Destroy on it when done.
+func (c *Context) GetFontFace() *FontFace {
+	defer runtime.KeepAlive(c)
+	return newFontFace(C.cairo_font_face_reference(C.cairo_get_font_face(c.context)))
+}
+
+// SetScaledFont sets the current font face, font matrix and font options from a scaled font.
+func (c *Context) SetScaledFont(scaledFont *ScaledFont) {
+	defer runtime.KeepAlive(c)
+	C.cairo_set_scaled_font(c.context, scaledFont.scaledFont)
+}
+
+// GetScaledFont returns the current scaled font. Call Destroy on it when done.
+func (c *Context) GetScaledFont() *ScaledFont {
+	defer runtime.KeepAlive(c)
+	return newScaledFont(C.cairo_scaled_font_reference(C.cairo_get_scaled_font(c.context)))
 }
 
 // GetStatus returns the status generated by the last operation.
 func (c *Context) GetStatus() Status {
+	defer runtime.KeepAlive(c)
 	return Status(C.cairo_status(c.context))
 }
 
-// Destroy tbd
+// Err returns the status generated by the last operation as an error, or nil if it succeeded.
+// Once a context is in an error state, all further drawing on it is ignored.
+// The returned error can be matched against Status constants with errors.Is.
+func (c *Context) Err() error {
+	if status := c.GetStatus(); status != StatusSuccess {
+		return status
+	}
+	return nil
+}
+
+// Reference returns a new Context owning another reference to this context.
+// The new value records shapes into the same shape registry, starting with the same shape id.
+func (c *Context) Reference() *Context {
+	defer runtime.KeepAlive(c)
+	context := newContext(C.cairo_reference(c.context), c.Surface, c.Width, c.Height)
+	context.shapes = c.shapes
+	context.shapeID = c.shapeID
+	return context
+}
+
+// Destroy releases the reference owned by this context, freeing it when no other references exist.
+// The context's surface is not destroyed. It is safe to call more than once.
 func (c *Context) Destroy() {
+	if !c.owned {
+		return
+	}
+	c.owned = false
+	runtime.SetFinalizer(c, nil)
 	C.cairo_destroy(c.context)
+	liveContexts.Add(-1)
+	untrackObject(c)
+}
+
+// GetReferenceCount gets the number of objects that are holding a reference to this context.
+func (c *Context) GetReferenceCount() int {
+	defer runtime.KeepAlive(c)
+	return int(C.cairo_get_reference_count(c.context))
 }
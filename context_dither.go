@@ -7,34 +7,64 @@ type DitherMethod func(int, int, int, int, int, []int)
 
 // DitherAtkinson dithers an image with the Atkinson algorithm
 func (c *Context) DitherAtkinson() {
-	c.dither(atkinson)
+	c.TryDitherAtkinson()
+}
+
+// TryDitherAtkinson is DitherAtkinson, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryDitherAtkinson() error {
+	return c.dither(atkinson)
 }
 
 // DitherFloydSteinberg dithers an image with the FloydSteinberg algorithm
 func (c *Context) DitherFloydSteinberg() {
-	c.dither(floydSteinberg)
+	c.TryDitherFloydSteinberg()
+}
+
+// TryDitherFloydSteinberg is DitherFloydSteinberg, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryDitherFloydSteinberg() error {
+	return c.dither(floydSteinberg)
 }
 
 // DitherJJN dithers an image with the FloydSteinberg algorithm
 func (c *Context) DitherJJN() {
-	c.dither(jjn)
+	c.TryDitherJJN()
+}
+
+// TryDitherJJN is DitherJJN, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryDitherJJN() error {
+	return c.dither(jjn)
 }
 
 // DitherSierra dithers an image with the FloydSteinberg algorithm
 func (c *Context) DitherSierra() {
-	c.dither(sierra)
+	c.TryDitherSierra()
+}
+
+// TryDitherSierra is DitherSierra, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryDitherSierra() error {
+	return c.dither(sierra)
 }
 
 // DitherStucki dithers an image with the FloydSteinberg algorithm
 func (c *Context) DitherStucki() {
-	c.dither(stucki)
+	c.TryDitherStucki()
+}
+
+// TryDitherStucki is DitherStucki, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryDitherStucki() error {
+	return c.dither(stucki)
 }
 
-func (c *Context) dither(ditherMethod DitherMethod) {
-	c.Grayscale()
+func (c *Context) dither(ditherMethod DitherMethod) error {
+	if err := c.TryGrayscale(); err != nil {
+		return err
+	}
 	width := int(c.Width)
 	height := int(c.Height)
-	data, _ := c.Surface.GetData()
+	data, err := c.Surface.getData32()
+	if err != nil {
+		return err
+	}
 
 	// copy bytes from data to int array grays
 	grays := make([]int, width*height)
@@ -69,8 +99,7 @@ func (c *Context) dither(ditherMethod DitherMethod) {
 		data[index+2] = byte(grays[i])
 		index += 4
 	}
-	c.Surface.SetData(data)
-
+	return c.Surface.SetData(data)
 }
 
 func atkinson(x, y, w, h, grayErr int, grays []int) {
@@ -2,6 +2,7 @@
 package cairo
 
 import (
+	"fmt"
 	"log"
 
 	"github.com/bit101/bitlib/blcolor"
@@ -180,20 +181,41 @@ func (c *Context) ProcessPixels(pixelFunc func(context *Context, x, y float64))
 }
 
 // PaintImage loads an image from an external png file and paints the context with that image.
+// It exits the program if the image cannot be loaded. Use TryPaintImage to handle the error instead.
 func (c *Context) PaintImage(imagePath string, x, y float64) {
+	if err := c.TryPaintImage(imagePath, x, y); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// TryPaintImage loads an image from an external png file and paints the context with that image,
+// returning an error if the image cannot be loaded.
+func (c *Context) TryPaintImage(imagePath string, x, y float64) error {
 	surface, err := NewSurfaceFromPNG(imagePath)
 	if err != nil {
-		log.Fatalf("could not load image: %s", err)
+		return fmt.Errorf("could not load image: %w", err)
 	}
+	defer surface.Destroy()
 	c.DrawSurface(surface, x, y)
+	return nil
 }
 
 // PaintImageCentered loads an image from an external png file and paints the context with that image.
+// It exits the program if the image cannot be loaded. Use TryPaintImageCentered to handle the error instead.
 func (c *Context) PaintImageCentered(imagePath string, x, y float64) {
+	if err := c.TryPaintImageCentered(imagePath, x, y); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// TryPaintImageCentered loads an image from an external png file and paints the context with that image,
+// centered on x, y, returning an error if the image cannot be loaded.
+func (c *Context) TryPaintImageCentered(imagePath string, x, y float64) error {
 	surface, err := NewSurfaceFromPNG(imagePath)
 	if err != nil {
-		log.Fatalf("could not load image: %s", err)
+		return fmt.Errorf("could not load image: %w", err)
 	}
+	defer surface.Destroy()
 	c.Save()
 	c.SetSourceSurface(surface, x-surface.GetWidthF()/2, y-surface.GetHeightF()/2)
 	c.Paint()
@@ -201,6 +223,7 @@ func (c *Context) PaintImageCentered(imagePath string, x, y float64) {
 	// may need to add to other methods?
 	c.SetSourceBlack()
 	c.Restore()
+	return nil
 }
 
 // DrawSurface draws the given surface onto this context with default composite operation.
@@ -9,14 +9,25 @@ import (
 )
 
 // SampleColors returns a pallet of colors from an external image.
+// It exits the program if the image cannot be loaded. Use TrySampleColors to handle the error instead.
 func SampleColors(image string, count int) *blcolor.Palette {
-	surface, err := NewSurfaceFromPNG(image)
+	palette, err := TrySampleColors(image, count)
 	if err != nil {
 		log.Fatal(err)
 	}
+	return palette
+}
+
+// TrySampleColors returns a pallet of colors from an external image, or an error if the image cannot be loaded.
+func TrySampleColors(image string, count int) (*blcolor.Palette, error) {
+	surface, err := NewSurfaceFromPNG(image)
+	if err != nil {
+		return nil, err
+	}
+	defer surface.Destroy()
 	data, err := surface.GetData()
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 	palette := blcolor.NewPalette()
 	for i := 0; i < count; i++ {
@@ -28,5 +39,5 @@ func SampleColors(image string, count int) *blcolor.Palette {
 		blue := float64(b) / 255
 		palette.AddRGB(red, green, blue)
 	}
-	return palette
+	return palette, nil
 }
@@ -2,6 +2,7 @@
 package cairo
 
 import (
+	"fmt"
 	"log"
 	"math"
 
@@ -12,10 +13,19 @@ import (
 // bt is the ImageData instance.
 // x, y is the top left of the sphere bounding box, diameter is the diameter of the sphere.
 // rotation is normalized. -1.0 is a full rotation to the right, 1.0 is a full rotation to the left.
+// It exits the program if the surface data cannot be accessed. Use TrySphereMap to handle the error instead.
 func (c *Context) SphereMap(bt ImageData, x, y, diameter, rotation float64) {
+	if err := c.TrySphereMap(bt, x, y, diameter, rotation); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// TrySphereMap maps an ImageData onto a sphere, returning an error if the surface data cannot be accessed.
+// The parameters are the same as for SphereMap.
+func (c *Context) TrySphereMap(bt ImageData, x, y, diameter, rotation float64) error {
 	buffer, err := ImageDataFromSurface(c.Surface)
 	if err != nil {
-		log.Fatalf("unable to create buffer: %s", err)
+		return fmt.Errorf("unable to create buffer: %w", err)
 	}
 	mapHeightF := float64(bt.Height)
 	mapWidthF := float64(bt.Width)
@@ -38,7 +48,7 @@ func (c *Context) SphereMap(bt ImageData, x, y, diameter, rotation float64) {
 			}
 		}
 	}
-	buffer.CopyToSurface(c.Surface)
+	return buffer.CopyToSurface(c.Surface)
 }
 
 // PolarMap maps an image to polar coordinates - essentially wraps it around in a donut shape.
@@ -48,10 +58,19 @@ func (c *Context) SphereMap(bt ImageData, x, y, diameter, rotation float64) {
 // cropTop and cropBottom discard that many pixels from the top and bottom of the source image.
 // rotation determines the starting point of the wrapped image. If rotation is 0, the image will wrap clockwise from the bottom of the donut.
 // mirror will mirror the image, allowing it to blend perfectly all the way around.
+// It exits the program if the surface data cannot be accessed. Use TryPolarMap to handle the error instead.
 func (c *Context) PolarMap(bt ImageData, cx, cy, outerRadius, innerRadius, cropTop, cropBottom, rotation float64, mirror bool) {
+	if err := c.TryPolarMap(bt, cx, cy, outerRadius, innerRadius, cropTop, cropBottom, rotation, mirror); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// TryPolarMap maps an image to polar coordinates, returning an error if the surface data cannot be accessed.
+// The parameters are the same as for PolarMap.
+func (c *Context) TryPolarMap(bt ImageData, cx, cy, outerRadius, innerRadius, cropTop, cropBottom, rotation float64, mirror bool) error {
 	buffer, err := ImageDataFromSurface(c.Surface)
 	if err != nil {
-		log.Fatalf("unable to create buffer: %s", err)
+		return fmt.Errorf("unable to create buffer: %w", err)
 	}
 	for x := cx - outerRadius; x < cx+outerRadius; x++ {
 		for y := cy - outerRadius; y < cy+outerRadius; y++ {
@@ -75,5 +94,5 @@ func (c *Context) PolarMap(bt ImageData, cx, cy, outerRadius, innerRadius, cropT
 			}
 		}
 	}
-	buffer.CopyToSurface(c.Surface)
+	return buffer.CopyToSurface(c.Surface)
 }
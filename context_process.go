@@ -2,6 +2,7 @@
 package cairo
 
 import (
+	"errors"
 	"math"
 
 	"github.com/bit101/bitlib/blcolor"
@@ -10,9 +11,47 @@ import (
 	"github.com/bit101/bitlib/random"
 )
 
+// Each filter here leaves the image unchanged if its pixel data cannot be read or written,
+// such as on a vector surface or, for most filters, an image surface not in the ARGB32 or RGB24 format.
+// The Try version of each filter returns the error instead.
+
+// getData32 returns the pixel data of a surface in one of the 32 bit formats, ARGB32 or RGB24,
+// which the filters that work directly on the bytes expect. Other formats return an error.
+func (s *Surface) getData32() ([]byte, error) {
+	if format := s.GetFormat(); format != FormatARGB32 && format != FormatRGB24 {
+		return nil, errors.New("cairo.Surface.getData32(): unsupported surface format")
+	}
+	return s.GetData()
+}
+
+// processRect copies a portion of the image to a temporary surface, runs process on a context for it,
+// and paints the result back in place. If process fails, the image is left unchanged.
+func (c *Context) processRect(rx, ry, rw, rh float64, process func(context *Context) error) error {
+	s := NewSurface(rw, rh)
+	defer s.Destroy()
+	context := NewContext(s)
+	defer context.Destroy()
+	context.SetSourceSurface(c.Surface, -rx, -ry)
+	context.Paint()
+	if err := process(context); err != nil {
+		return err
+	}
+	c.SetSourceSurface(s, rx, ry)
+	c.Paint()
+	return nil
+}
+
 // Grayscale turns the image grayscale.
 func (c *Context) Grayscale() {
-	data, _ := c.Surface.GetData()
+	c.TryGrayscale()
+}
+
+// TryGrayscale is Grayscale, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryGrayscale() error {
+	data, err := c.Surface.getData32()
+	if err != nil {
+		return err
+	}
 	r := 0.299
 	g := 0.587
 	b := 0.113
@@ -23,23 +62,32 @@ func (c *Context) Grayscale() {
 		data[i+1] = val
 		data[i+2] = val
 	}
-	c.Surface.SetData(data)
+	return c.Surface.SetData(data)
 }
 
 // GrayscaleRect turns a portion of the image grayscale.
 func (c *Context) GrayscaleRect(rx, ry, rw, rh float64) {
-	s := NewSurface(rw, rh)
-	context := NewContext(s)
-	context.SetSourceSurface(c.Surface, -rx, -ry)
-	context.Paint()
-	context.Grayscale()
-	c.SetSourceSurface(s, rx, ry)
-	c.Paint()
+	c.TryGrayscaleRect(rx, ry, rw, rh)
+}
+
+// TryGrayscaleRect is GrayscaleRect, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryGrayscaleRect(rx, ry, rw, rh float64) error {
+	return c.processRect(rx, ry, rw, rh, func(context *Context) error {
+		return context.TryGrayscale()
+	})
 }
 
 // Threshold sets any pixel whose average value is below t to the given rgba value.
 func (c *Context) Threshold(t, r, g, b, a float64) {
-	data, _ := c.Surface.GetData()
+	c.TryThreshold(t, r, g, b, a)
+}
+
+// TryThreshold is Threshold, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryThreshold(t, r, g, b, a float64) error {
+	data, err := c.Surface.getData32()
+	if err != nil {
+		return err
+	}
 	for i := 0; i < len(data); i += 4 {
 		val := float64(data[i]) / 255
 		val += float64(data[i+1]) / 255
@@ -52,23 +100,32 @@ func (c *Context) Threshold(t, r, g, b, a float64) {
 			data[i+3] = byte(a * 255)
 		}
 	}
-	c.Surface.SetData(data)
+	return c.Surface.SetData(data)
 }
 
 // ThresholdRect performs a threshold operation on a portion of an image.
 func (c *Context) ThresholdRect(rx, ry, rw, rh, t, r, g, b, a float64) {
-	s := NewSurface(rw, rh)
-	context := NewContext(s)
-	context.SetSourceSurface(c.Surface, -rx, -ry)
-	context.Paint()
-	context.Threshold(t, r, g, b, a)
-	c.SetSourceSurface(s, rx, ry)
-	c.Paint()
+	c.TryThresholdRect(rx, ry, rw, rh, t, r, g, b, a)
+}
+
+// TryThresholdRect is ThresholdRect, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryThresholdRect(rx, ry, rw, rh, t, r, g, b, a float64) error {
+	return c.processRect(rx, ry, rw, rh, func(context *Context) error {
+		return context.TryThreshold(t, r, g, b, a)
+	})
 }
 
 // ReverseThreshold sets any pixel whose average value is greater than t to the given rgba value.
 func (c *Context) ReverseThreshold(t, r, g, b, a float64) {
-	data, _ := c.Surface.GetData()
+	c.TryReverseThreshold(t, r, g, b, a)
+}
+
+// TryReverseThreshold is ReverseThreshold, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryReverseThreshold(t, r, g, b, a float64) error {
+	data, err := c.Surface.getData32()
+	if err != nil {
+		return err
+	}
 	for i := 0; i < len(data); i += 4 {
 		val := float64(data[i]) / 255
 		val += float64(data[i+1]) / 255
@@ -81,25 +138,34 @@ func (c *Context) ReverseThreshold(t, r, g, b, a float64) {
 			data[i+3] = byte(a * 255)
 		}
 	}
-	c.Surface.SetData(data)
+	return c.Surface.SetData(data)
 }
 
 // ReverseThresholdRect performs a reverse threshold operation on a portion of an images.
 func (c *Context) ReverseThresholdRect(rx, ry, rw, rh, t, r, g, b, a float64) {
-	s := NewSurface(rw, rh)
-	context := NewContext(s)
-	context.SetSourceSurface(c.Surface, -rx, -ry)
-	context.Paint()
-	context.ReverseThreshold(t, r, g, b, a)
-	c.SetSourceSurface(s, rx, ry)
-	c.Paint()
+	c.TryReverseThresholdRect(rx, ry, rw, rh, t, r, g, b, a)
+}
+
+// TryReverseThresholdRect is ReverseThresholdRect, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryReverseThresholdRect(rx, ry, rw, rh, t, r, g, b, a float64) error {
+	return c.processRect(rx, ry, rw, rh, func(context *Context) error {
+		return context.TryReverseThreshold(t, r, g, b, a)
+	})
 }
 
 // Quantize reduces the number of colors in an image.
 // Technically, it quantizes the values of each pixel separately,
 // so the result will have more than t colors.
 func (c *Context) Quantize(t int) {
-	data, _ := c.Surface.GetData()
+	c.TryQuantize(t)
+}
+
+// TryQuantize is Quantize, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryQuantize(t int) error {
+	data, err := c.Surface.getData32()
+	if err != nil {
+		return err
+	}
 	for i := 0; i < len(data); i += 4 {
 		for j := 0; j < 3; j++ {
 			val := float64(data[i+j])
@@ -107,25 +173,34 @@ func (c *Context) Quantize(t int) {
 			data[i+j] = byte(val)
 		}
 	}
-	c.Surface.SetData(data)
+	return c.Surface.SetData(data)
 }
 
 // QuantizeRect quantizes a portion of an image.
 func (c *Context) QuantizeRect(rx, ry, rw, rh float64, t int) {
-	s := NewSurface(rw, rh)
-	context := NewContext(s)
-	context.SetSourceSurface(c.Surface, -rx, -ry)
-	context.Paint()
-	context.Quantize(t)
-	c.SetSourceSurface(s, rx, ry)
-	c.Paint()
+	c.TryQuantizeRect(rx, ry, rw, rh, t)
+}
+
+// TryQuantizeRect is QuantizeRect, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryQuantizeRect(rx, ry, rw, rh float64, t int) error {
+	return c.processRect(rx, ry, rw, rh, func(context *Context) error {
+		return context.TryQuantize(t)
+	})
 }
 
 // Gamma does gamma correction on an image.
 // gamma values less than 1.0 darken the image, greater than 1.0 lighten it.
 func (c *Context) Gamma(gamma float64) {
+	c.TryGamma(gamma)
+}
+
+// TryGamma is Gamma, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryGamma(gamma float64) error {
 	gammaCorrection := 1.0 / gamma
-	data, _ := c.Surface.GetData()
+	data, err := c.Surface.getData32()
+	if err != nil {
+		return err
+	}
 	for i := 0; i < len(data); i += 4 {
 		for j := 0; j < 3; j++ {
 			val := float64(data[i+j]) / 255.0
@@ -133,47 +208,65 @@ func (c *Context) Gamma(gamma float64) {
 			data[i+j] = byte(val * 255)
 		}
 	}
-	c.Surface.SetData(data)
+	return c.Surface.SetData(data)
 }
 
 // GammaRect gamma corrects a portion of an image.
 func (c *Context) GammaRect(rx, ry, rw, rh, gamma float64) {
-	s := NewSurface(rw, rh)
-	context := NewContext(s)
-	context.SetSourceSurface(c.Surface, -rx, -ry)
-	context.Paint()
-	context.Gamma(gamma)
-	c.SetSourceSurface(s, rx, ry)
-	c.Paint()
+	c.TryGammaRect(rx, ry, rw, rh, gamma)
+}
+
+// TryGammaRect is GammaRect, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryGammaRect(rx, ry, rw, rh, gamma float64) error {
+	return c.processRect(rx, ry, rw, rh, func(context *Context) error {
+		return context.TryGamma(gamma)
+	})
 }
 
 // Invert inverts the colors of an image.
 func (c *Context) Invert() {
-	data, _ := c.Surface.GetData()
+	c.TryInvert()
+}
+
+// TryInvert is Invert, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryInvert() error {
+	data, err := c.Surface.getData32()
+	if err != nil {
+		return err
+	}
 	for i := 0; i < len(data); i += 4 {
 		for j := 0; j < 3; j++ {
 			data[i+j] = 255 - data[i+j]
 		}
 	}
-	c.Surface.SetData(data)
+	return c.Surface.SetData(data)
 }
 
 // InvertRect inverts the colors in a portion of an image.
 func (c *Context) InvertRect(rx, ry, rw, rh float64) {
-	s := NewSurface(rw, rh)
-	context := NewContext(s)
-	context.SetSourceSurface(c.Surface, -rx, -ry)
-	context.Paint()
-	context.Invert()
-	c.SetSourceSurface(s, rx, ry)
-	c.Paint()
+	c.TryInvertRect(rx, ry, rw, rh)
+}
+
+// TryInvertRect is InvertRect, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryInvertRect(rx, ry, rw, rh float64) error {
+	return c.processRect(rx, ry, rw, rh, func(context *Context) error {
+		return context.TryInvert()
+	})
 }
 
 // Contrast changes the balance of dark and light areas in an image.
 func (c *Context) Contrast(amt float64) {
+	c.TryContrast(amt)
+}
+
+// TryContrast is Contrast, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryContrast(amt float64) error {
 	cont := 255.0 * amt
 	f := (259.0 * (cont + 255.0)) / (255 * (259.0 - cont))
-	data, _ := c.Surface.GetData()
+	data, err := c.Surface.getData32()
+	if err != nil {
+		return err
+	}
 	for i := 0; i < len(data); i += 4 {
 		for j := 0; j < 3; j++ {
 			val := float64(data[i+j])
@@ -182,24 +275,33 @@ func (c *Context) Contrast(amt float64) {
 			data[i+j] = byte(val)
 		}
 	}
-	c.Surface.SetData(data)
+	return c.Surface.SetData(data)
 }
 
 // ContrastRect adjusts the contrast in a portion of an image.
 func (c *Context) ContrastRect(rx, ry, rw, rh, amt float64) {
-	s := NewSurface(rw, rh)
-	context := NewContext(s)
-	context.SetSourceSurface(c.Surface, -rx, -ry)
-	context.Paint()
-	context.Contrast(amt)
-	c.SetSourceSurface(s, rx, ry)
-	c.Paint()
+	c.TryContrastRect(rx, ry, rw, rh, amt)
+}
+
+// TryContrastRect is ContrastRect, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryContrastRect(rx, ry, rw, rh, amt float64) error {
+	return c.processRect(rx, ry, rw, rh, func(context *Context) error {
+		return context.TryContrast(amt)
+	})
 }
 
 // Brightness adjusts the brightness of an image.
 func (c *Context) Brightness(amt float64) {
+	c.TryBrightness(amt)
+}
+
+// TryBrightness is Brightness, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryBrightness(amt float64) error {
 	brightness := 255.0 * amt
-	data, _ := c.Surface.GetData()
+	data, err := c.Surface.getData32()
+	if err != nil {
+		return err
+	}
 	for i := 0; i < len(data); i += 4 {
 		for j := 0; j < 3; j++ {
 			val := float64(data[i+j]) + brightness
@@ -207,25 +309,34 @@ func (c *Context) Brightness(amt float64) {
 			data[i+j] = byte(val)
 		}
 	}
-	c.Surface.SetData(data)
+	return c.Surface.SetData(data)
 }
 
 // BrightnessRect adjusts the brightness of a portion of an image.
 func (c *Context) BrightnessRect(rx, ry, rw, rh, amt float64) {
-	s := NewSurface(rw, rh)
-	context := NewContext(s)
-	context.SetSourceSurface(c.Surface, -rx, -ry)
-	context.Paint()
-	context.Brightness(amt)
-	c.SetSourceSurface(s, rx, ry)
-	c.Paint()
+	c.TryBrightnessRect(rx, ry, rw, rh, amt)
+}
+
+// TryBrightnessRect is BrightnessRect, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryBrightnessRect(rx, ry, rw, rh, amt float64) error {
+	return c.processRect(rx, ry, rw, rh, func(context *Context) error {
+		return context.TryBrightness(amt)
+	})
 }
 
 // Tint tints an image.
 // r, g, b, determine the color of the tint.
 // t determines how much the tint is applied. t=1 will result in the entire image being a single color.
 func (c *Context) Tint(r, g, b, t float64) {
-	data, _ := c.Surface.GetData()
+	c.TryTint(r, g, b, t)
+}
+
+// TryTint is Tint, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryTint(r, g, b, t float64) error {
+	data, err := c.Surface.getData32()
+	if err != nil {
+		return err
+	}
 	for i := 0; i < len(data); i += 4 {
 		val := float64(data[i])
 		val = blmath.Lerp(t, val, b*255)
@@ -242,47 +353,62 @@ func (c *Context) Tint(r, g, b, t float64) {
 		val = blmath.Clamp(val, 0, 255)
 		data[i+2] = byte(val)
 	}
-	c.Surface.SetData(data)
+	return c.Surface.SetData(data)
 }
 
 // TintRect tints a portion of an image.
 func (c *Context) TintRect(rx, ry, rw, rh, r, g, b, t float64) {
-	s := NewSurface(rw, rh)
-	context := NewContext(s)
-	context.SetSourceSurface(c.Surface, -rx, -ry)
-	context.Paint()
-	context.Tint(r, g, b, t)
-	c.SetSourceSurface(s, rx, ry)
-	c.Paint()
+	c.TryTintRect(rx, ry, rw, rh, r, g, b, t)
+}
+
+// TryTintRect is TintRect, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryTintRect(rx, ry, rw, rh, r, g, b, t float64) error {
+	return c.processRect(rx, ry, rw, rh, func(context *Context) error {
+		return context.TryTint(r, g, b, t)
+	})
 }
 
 // Hue tints an image to a given hue
 func (c *Context) Hue(hue, t float64) {
+	c.TryHue(hue, t)
+}
+
+// TryHue is Hue, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryHue(hue, t float64) error {
 	color := blcolor.HSV(hue, 1, 1)
-	c.Tint(color.R, color.G, color.B, t)
+	return c.TryTint(color.R, color.G, color.B, t)
 }
 
 // HueRect tints a portion of an image to a given hue.
 func (c *Context) HueRect(rx, ry, rw, rh, hue, t float64) {
-	s := NewSurface(rw, rh)
-	context := NewContext(s)
-	context.SetSourceSurface(c.Surface, -rx, -ry)
-	context.Paint()
-	context.Hue(hue, t)
-	c.SetSourceSurface(s, rx, ry)
-	c.Paint()
+	c.TryHueRect(rx, ry, rw, rh, hue, t)
+}
+
+// TryHueRect is HueRect, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryHueRect(rx, ry, rw, rh, hue, t float64) error {
+	return c.processRect(rx, ry, rw, rh, func(context *Context) error {
+		return context.TryHue(hue, t)
+	})
 }
 
 // Blur executes a box blur.
 func (c *Context) Blur(radius int) {
+	c.TryBlur(radius)
+}
+
+// TryBlur is Blur, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryBlur(radius int) error {
 	if radius < 1 {
 		// blur of 0 does nothing.
 		// blur of less than 0 is wrong. ignore.
-		return
+		return nil
 	}
 
-	srcIm, _ := ImageDataFromSurface(c.Surface)
-	dstIm := NewImageData(srcIm.Width, srcIm.Height)
+	srcIm, err := ImageDataFromSurface(c.Surface)
+	if err != nil {
+		return err
+	}
+	dstIm := NewImageDataWithFormat(srcIm.Format(), srcIm.Width, srcIm.Height)
 	w := int(c.Width)
 	h := int(c.Height)
 	// doing a two-pass (h+v) blur is O(m^2*2n) m=bitmap size, n = kernel size
@@ -318,31 +444,40 @@ func (c *Context) Blur(radius int) {
 		}
 	}
 	// the final pass put the bytes in src, so we copy that back.
-	srcIm.CopyToSurface(c.Surface)
+	return srcIm.CopyToSurface(c.Surface)
 }
 
 // BlurRect executes a box blur on a portion of an image.
 func (c *Context) BlurRect(rx, ry, rw, rh float64, radius int) {
-	s := NewSurface(rw, rh)
-	context := NewContext(s)
-	context.SetSourceSurface(c.Surface, -rx, -ry)
-	context.Paint()
-	context.Blur(radius)
-	c.SetSourceSurface(s, rx, ry)
-	c.Paint()
+	c.TryBlurRect(rx, ry, rw, rh, radius)
+}
+
+// TryBlurRect is BlurRect, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryBlurRect(rx, ry, rw, rh float64, radius int) error {
+	return c.processRect(rx, ry, rw, rh, func(context *Context) error {
+		return context.TryBlur(radius)
+	})
 }
 
 // GaussianBlur executes a Gaussian blur.
 func (c *Context) GaussianBlur(radius int) {
+	c.TryGaussianBlur(radius)
+}
+
+// TryGaussianBlur is GaussianBlur, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryGaussianBlur(radius int) error {
 	if radius < 1 {
 		// blur of 0 does nothing.
 		// less than 0 is just wrong. we'll ignore.
-		return
+		return nil
 	}
 	kernel := getGaussKernel(radius*2 + 1)
 
-	srcIm, _ := ImageDataFromSurface(c.Surface)
-	dstIm := NewImageData(srcIm.Width, srcIm.Height)
+	srcIm, err := ImageDataFromSurface(c.Surface)
+	if err != nil {
+		return err
+	}
+	dstIm := NewImageDataWithFormat(srcIm.Format(), srcIm.Width, srcIm.Height)
 	w := int(c.Width)
 	h := int(c.Height)
 
@@ -379,18 +514,19 @@ func (c *Context) GaussianBlur(radius int) {
 		}
 	}
 	// the final pass put the bytes in src, so we copy that back.
-	srcIm.CopyToSurface(c.Surface)
+	return srcIm.CopyToSurface(c.Surface)
 }
 
 // GaussianBlurRect executes a Gaussian blur on a portion of an image.
 func (c *Context) GaussianBlurRect(rx, ry, rw, rh float64, radius int) {
-	s := NewSurface(rw, rh)
-	context := NewContext(s)
-	context.SetSourceSurface(c.Surface, -rx, -ry)
-	context.Paint()
-	context.GaussianBlur(radius)
-	c.SetSourceSurface(s, rx, ry)
-	c.Paint()
+	c.TryGaussianBlurRect(rx, ry, rw, rh, radius)
+}
+
+// TryGaussianBlurRect is GaussianBlurRect, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryGaussianBlurRect(rx, ry, rw, rh float64, radius int) error {
+	return c.processRect(rx, ry, rw, rh, func(context *Context) error {
+		return context.TryGaussianBlur(radius)
+	})
 }
 
 func getGaussKernel(size int) []float64 {
@@ -411,7 +547,15 @@ func getGaussKernel(size int) []float64 {
 
 // Pixelate pixelates an image.
 func (c *Context) Pixelate(size int) {
-	srcIm, _ := ImageDataFromSurface(c.Surface)
+	c.TryPixelate(size)
+}
+
+// TryPixelate is Pixelate, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryPixelate(size int) error {
+	srcIm, err := ImageDataFromSurface(c.Surface)
+	if err != nil {
+		return err
+	}
 	w := int(c.Width)
 	h := int(c.Height)
 
@@ -422,23 +566,33 @@ func (c *Context) Pixelate(size int) {
 			c.FillRectangle(float64(x), float64(y), float64(size), float64(size))
 		}
 	}
+	return nil
 }
 
 // PixelateRect pixelates a portion of an image.
 func (c *Context) PixelateRect(rx, ry, rw, rh float64, size int) {
-	s := NewSurface(rw, rh)
-	context := NewContext(s)
-	context.SetSourceSurface(c.Surface, -rx, -ry)
-	context.Paint()
-	context.Pixelate(size)
-	c.SetSourceSurface(s, rx, ry)
-	c.Paint()
+	c.TryPixelateRect(rx, ry, rw, rh, size)
+}
+
+// TryPixelateRect is PixelateRect, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryPixelateRect(rx, ry, rw, rh float64, size int) error {
+	return c.processRect(rx, ry, rw, rh, func(context *Context) error {
+		return context.TryPixelate(size)
+	})
 }
 
 // Sharpen executes a sharpen filter.
 func (c *Context) Sharpen() {
-	srcIm, _ := ImageDataFromSurface(c.Surface)
-	dstIm := NewImageData(srcIm.Width, srcIm.Height)
+	c.TrySharpen()
+}
+
+// TrySharpen is Sharpen, returning an error if the pixel data cannot be read or written.
+func (c *Context) TrySharpen() error {
+	srcIm, err := ImageDataFromSurface(c.Surface)
+	if err != nil {
+		return err
+	}
+	dstIm := NewImageDataWithFormat(srcIm.Format(), srcIm.Width, srcIm.Height)
 	w := int(c.Width)
 	h := int(c.Height)
 	kernel := [][]float64{{0, -1, 0}, {-1, 5, -1}, {0, -1, 0}}
@@ -461,26 +615,37 @@ func (c *Context) Sharpen() {
 			dstIm.SetPixel(x, y, r, g, b, 1)
 		}
 	}
-	dstIm.CopyToSurface(c.Surface)
+	return dstIm.CopyToSurface(c.Surface)
 }
 
 // SharpenRect sharpens a portion of an image.
 func (c *Context) SharpenRect(rx, ry, rw, rh float64) {
-	s := NewSurface(rw, rh)
-	context := NewContext(s)
-	context.SetSourceSurface(c.Surface, -rx, -ry)
-	context.Paint()
-	context.Sharpen()
-	c.SetSourceSurface(s, rx, ry)
-	c.Paint()
+	c.TrySharpenRect(rx, ry, rw, rh)
+}
+
+// TrySharpenRect is SharpenRect, returning an error if the pixel data cannot be read or written.
+func (c *Context) TrySharpenRect(rx, ry, rw, rh float64) error {
+	return c.processRect(rx, ry, rw, rh, func(context *Context) error {
+		return context.TrySharpen()
+	})
 }
 
 // MapGradient maps the brightness values in an image to a gradient between two colors.
 func (c *Context) MapGradient(col0, col1 blcolor.Color) {
+	c.TryMapGradient(col0, col1)
+}
+
+// TryMapGradient is MapGradient, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryMapGradient(col0, col1 blcolor.Color) error {
 	w := int(c.Width)
 	h := int(c.Height)
-	c.Grayscale()
-	srcIm, _ := ImageDataFromSurface(c.Surface)
+	if err := c.TryGrayscale(); err != nil {
+		return err
+	}
+	srcIm, err := ImageDataFromSurface(c.Surface)
+	if err != nil {
+		return err
+	}
 	for x := 0; x < w; x++ {
 		for y := 0; y < h; y++ {
 			r, _, _, a := srcIm.GetPixel(x, y)
@@ -488,26 +653,37 @@ func (c *Context) MapGradient(col0, col1 blcolor.Color) {
 			srcIm.SetPixel(x, y, c.R, c.G, c.B, a)
 		}
 	}
-	srcIm.CopyToSurface(c.Surface)
+	return srcIm.CopyToSurface(c.Surface)
 }
 
 // MapGradientRect performs a map gradient operation on a portion of an image.
 func (c *Context) MapGradientRect(rx, ry, rw, rh float64, col0, col1 blcolor.Color) {
-	s := NewSurface(rw, rh)
-	context := NewContext(s)
-	context.SetSourceSurface(c.Surface, -rx, -ry)
-	context.Paint()
-	context.MapGradient(col0, col1)
-	c.SetSourceSurface(s, rx, ry)
-	c.Paint()
+	c.TryMapGradientRect(rx, ry, rw, rh, col0, col1)
+}
+
+// TryMapGradientRect is MapGradientRect, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryMapGradientRect(rx, ry, rw, rh float64, col0, col1 blcolor.Color) error {
+	return c.processRect(rx, ry, rw, rh, func(context *Context) error {
+		return context.TryMapGradient(col0, col1)
+	})
 }
 
 // MapGradientArray maps the brightness values in an image to a color palette.
 func (c *Context) MapGradientArray(colorMap blcolor.Palette) {
+	c.TryMapGradientArray(colorMap)
+}
+
+// TryMapGradientArray is MapGradientArray, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryMapGradientArray(colorMap blcolor.Palette) error {
 	w := int(c.Width)
 	h := int(c.Height)
-	c.Grayscale()
-	srcIm, _ := ImageDataFromSurface(c.Surface)
+	if err := c.TryGrayscale(); err != nil {
+		return err
+	}
+	srcIm, err := ImageDataFromSurface(c.Surface)
+	if err != nil {
+		return err
+	}
 	for x := 0; x < w; x++ {
 		for y := 0; y < h; y++ {
 			r, _, _, a := srcIm.GetPixel(x, y)
@@ -516,26 +692,37 @@ func (c *Context) MapGradientArray(colorMap blcolor.Palette) {
 			srcIm.SetPixel(x, y, c.R, c.G, c.B, a)
 		}
 	}
-	srcIm.CopyToSurface(c.Surface)
+	return srcIm.CopyToSurface(c.Surface)
 }
 
 // MapGradientArrayRect maps the brightness values of a portion of an image to a color values.
 func (c *Context) MapGradientArrayRect(rx, ry, rw, rh float64, colorMap blcolor.Palette) {
-	s := NewSurface(rw, rh)
-	context := NewContext(s)
-	context.SetSourceSurface(c.Surface, -rx, -ry)
-	context.Paint()
-	context.MapGradientArray(colorMap)
-	c.SetSourceSurface(s, rx, ry)
-	c.Paint()
+	c.TryMapGradientArrayRect(rx, ry, rw, rh, colorMap)
+}
+
+// TryMapGradientArrayRect is MapGradientArrayRect, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryMapGradientArrayRect(rx, ry, rw, rh float64, colorMap blcolor.Palette) error {
+	return c.processRect(rx, ry, rw, rh, func(context *Context) error {
+		return context.TryMapGradientArray(colorMap)
+	})
 }
 
 // MapHue maps the brightness values in an image to a gradient between two hues.
 func (c *Context) MapHue(hue0, hue1 float64) {
+	c.TryMapHue(hue0, hue1)
+}
+
+// TryMapHue is MapHue, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryMapHue(hue0, hue1 float64) error {
 	w := int(c.Width)
 	h := int(c.Height)
-	c.Grayscale()
-	srcIm, _ := ImageDataFromSurface(c.Surface)
+	if err := c.TryGrayscale(); err != nil {
+		return err
+	}
+	srcIm, err := ImageDataFromSurface(c.Surface)
+	if err != nil {
+		return err
+	}
 	for x := 0; x < w; x++ {
 		for y := 0; y < h; y++ {
 			r, _, _, a := srcIm.GetPixel(x, y)
@@ -543,25 +730,34 @@ func (c *Context) MapHue(hue0, hue1 float64) {
 			srcIm.SetPixel(x, y, c.R, c.G, c.B, a)
 		}
 	}
-	srcIm.CopyToSurface(c.Surface)
+	return srcIm.CopyToSurface(c.Surface)
 }
 
 // MapHueRect performs a map hue operation on a portion of an image.
 func (c *Context) MapHueRect(rx, ry, rw, rh, hue0, hue1 float64) {
-	s := NewSurface(rw, rh)
-	context := NewContext(s)
-	context.SetSourceSurface(c.Surface, -rx, -ry)
-	context.Paint()
-	context.MapHue(hue0, hue1)
-	c.SetSourceSurface(s, rx, ry)
-	c.Paint()
+	c.TryMapHueRect(rx, ry, rw, rh, hue0, hue1)
+}
+
+// TryMapHueRect is MapHueRect, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryMapHueRect(rx, ry, rw, rh, hue0, hue1 float64) error {
+	return c.processRect(rx, ry, rw, rh, func(context *Context) error {
+		return context.TryMapHue(hue0, hue1)
+	})
 }
 
 // Noisify adds noise to an image.
 func (c *Context) Noisify(amount float64) {
+	c.TryNoisify(amount)
+}
+
+// TryNoisify is Noisify, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryNoisify(amount float64) error {
 	w := int(c.Width)
 	h := int(c.Height)
-	srcIm, _ := ImageDataFromSurface(c.Surface)
+	srcIm, err := ImageDataFromSurface(c.Surface)
+	if err != nil {
+		return err
+	}
 	for x := 0; x < w; x++ {
 		for y := 0; y < h; y++ {
 			r, g, b, a := srcIm.GetPixel(x, y)
@@ -571,18 +767,19 @@ func (c *Context) Noisify(amount float64) {
 			srcIm.SetPixel(x, y, blmath.Clamp(r, 0, 1), blmath.Clamp(g, 0, 1), blmath.Clamp(b, 0, 1), a)
 		}
 	}
-	srcIm.CopyToSurface(c.Surface)
+	return srcIm.CopyToSurface(c.Surface)
 }
 
 // NoisifyRect applies noise to a portion of an image.
 func (c *Context) NoisifyRect(rx, ry, rw, rh, amount float64) {
-	s := NewSurface(rw, rh)
-	context := NewContext(s)
-	context.SetSourceSurface(c.Surface, -rx, -ry)
-	context.Paint()
-	context.Noisify(amount)
-	c.SetSourceSurface(s, rx, ry)
-	c.Paint()
+	c.TryNoisifyRect(rx, ry, rw, rh, amount)
+}
+
+// TryNoisifyRect is NoisifyRect, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryNoisifyRect(rx, ry, rw, rh, amount float64) error {
+	return c.processRect(rx, ry, rw, rh, func(context *Context) error {
+		return context.TryNoisify(amount)
+	})
 }
 
 // DrawContext sets the source of this context to the surface of another context and paints.
@@ -594,58 +791,75 @@ func (c *Context) DrawContext(src *Context, x, y float64) {
 // FilterChannels selectively filters out each rgb channel by scaling it from 0 to 1.
 // Example: to get only the red channel, context.FilterChannels(1, 0, 0)
 func (s *Surface) FilterChannels(r, g, b float64) {
-	data, _ := s.GetData()
+	s.TryFilterChannels(r, g, b)
+}
+
+// TryFilterChannels is FilterChannels, returning an error if the pixel data cannot be read or written.
+func (s *Surface) TryFilterChannels(r, g, b float64) error {
+	data, err := s.getData32()
+	if err != nil {
+		return err
+	}
 	for i := 0; i < len(data); i += 4 {
 		data[i] = byte(float64(data[i]) * b)
 		data[i+1] = byte(float64(data[i+1]) * g)
 		data[i+2] = byte(float64(data[i+2]) * r)
 		data[i+3] = 128
 	}
-	s.SetData(data)
+	return s.SetData(data)
 }
 
 // ColorFringe applies a chromatic abberation effect, seperating the rgb color channels horizontally by a given amount.
 func (c *Context) ColorFringe(offset float64) {
-	c.Save()
+	c.TryColorFringe(offset)
+}
 
+// TryColorFringe is ColorFringe, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryColorFringe(offset float64) error {
 	// get image data
-	data, _ := c.Surface.GetData()
+	data, err := c.Surface.getData32()
+	if err != nil {
+		return err
+	}
 	s := NewSurface(c.Width, c.Height)
+	defer s.Destroy()
+
+	c.Save()
+	defer c.Restore()
 
 	// clear image to black and set screen operator
 	c.ClearBlack()
 	c.SetOperator(OperatorScreen)
 
-	// draw the red channel shifted right
-	s.SetData(data)
-	s.FilterChannels(1, 0, 0)
-	c.SetSourceSurface(s, offset, 0)
-	c.Paint()
-
-	// draw the green channel, no shift
-	s.SetData(data)
-	s.FilterChannels(0, 1, 0)
-	c.SetSourceSurface(s, 0, 0)
-	c.Paint()
-
-	// draw the blue channel shifted left
-	s.SetData(data)
-	s.FilterChannels(0, 0, 1)
-	c.SetSourceSurface(s, -offset, 0)
-	c.Paint()
-
-	c.Restore()
+	// draw the red channel shifted right, the green channel with no shift, and the blue channel shifted left.
+	channels := []struct{ r, g, b, offset float64 }{
+		{1, 0, 0, offset},
+		{0, 1, 0, 0},
+		{0, 0, 1, -offset},
+	}
+	for _, channel := range channels {
+		if err := s.SetData(data); err != nil {
+			return err
+		}
+		if err := s.TryFilterChannels(channel.r, channel.g, channel.b); err != nil {
+			return err
+		}
+		c.SetSourceSurface(s, channel.offset, 0)
+		c.Paint()
+	}
+	return nil
 }
 
 // ColorFringeRect performs a color fringe operation on a portion of an image.
 func (c *Context) ColorFringeRect(rx, ry, rw, rh, offset float64) {
-	s := NewSurface(rw, rh)
-	context := NewContext(s)
-	context.SetSourceSurface(c.Surface, -rx, -ry)
-	context.Paint()
-	context.ColorFringe(offset)
-	c.SetSourceSurface(s, rx, ry)
-	c.Paint()
+	c.TryColorFringeRect(rx, ry, rw, rh, offset)
+}
+
+// TryColorFringeRect is ColorFringeRect, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryColorFringeRect(rx, ry, rw, rh, offset float64) error {
+	return c.processRect(rx, ry, rw, rh, func(context *Context) error {
+		return context.TryColorFringe(offset)
+	})
 }
 
 // WarpNoise warps an image with Simplex noise.
@@ -655,10 +869,16 @@ func (c *Context) ColorFringeRect(rx, ry, rw, rh, offset float64) {
 // centerX and Y control the center of the noise field. Most useful when animating freq.
 // z is the z param of Simplex3. Can be used to animate the noise.
 func (c *Context) WarpNoise(freq, offset, rotation, centerX, centerY, z float64) {
-	s := NewSurface(c.Width, c.Height)
-	dstData, _ := ImageDataFromSurface(s)
+	c.TryWarpNoise(freq, offset, rotation, centerX, centerY, z)
+}
 
-	srcData, _ := ImageDataFromSurface(c.Surface)
+// TryWarpNoise is WarpNoise, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryWarpNoise(freq, offset, rotation, centerX, centerY, z float64) error {
+	srcData, err := ImageDataFromSurface(c.Surface)
+	if err != nil {
+		return err
+	}
+	dstData := NewImageDataWithFormat(srcData.Format(), srcData.Width, srcData.Height)
 	for x := 0.0; x < c.Width; x++ {
 		for y := 0.0; y < c.Height; y++ {
 			x1 := (x - centerX) / c.Width * freq
@@ -681,7 +901,7 @@ func (c *Context) WarpNoise(freq, offset, rotation, centerX, centerY, z float64)
 			dstData.SetPixel(int(x), int(y), r, g, b, a)
 		}
 	}
-	c.Surface.SetData(dstData.data)
+	return c.Surface.SetData(dstData.data)
 }
 
 // WarpRipple warps the image with a ripple like effect.
@@ -690,9 +910,16 @@ func (c *Context) WarpNoise(freq, offset, rotation, centerX, centerY, z float64)
 // offset determines the hight of the ripple.
 // phase moves the wave. Increasing phase from 0 to 1 will make the wave move out from the center a full cycle.
 func (c *Context) WarpRipple(centerX, centerY, spacing, offset, phase float64) {
-	s := NewSurface(c.Width, c.Height)
-	dstData, _ := ImageDataFromSurface(s)
-	srcData, _ := ImageDataFromSurface(c.Surface)
+	c.TryWarpRipple(centerX, centerY, spacing, offset, phase)
+}
+
+// TryWarpRipple is WarpRipple, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryWarpRipple(centerX, centerY, spacing, offset, phase float64) error {
+	srcData, err := ImageDataFromSurface(c.Surface)
+	if err != nil {
+		return err
+	}
+	dstData := NewImageDataWithFormat(srcData.Format(), srcData.Width, srcData.Height)
 	// rings := radius / spacing
 
 	for x := 0.0; x < c.Width; x++ {
@@ -714,7 +941,7 @@ func (c *Context) WarpRipple(centerX, centerY, spacing, offset, phase float64) {
 			dstData.SetPixel(int(x), int(y), r, g, b, a)
 		}
 	}
-	c.Surface.SetData(dstData.data)
+	return c.Surface.SetData(dstData.data)
 }
 
 // WarpRippleRadius warps the image with a ripple like effect, constrained by a radius.
@@ -725,9 +952,16 @@ func (c *Context) WarpRipple(centerX, centerY, spacing, offset, phase float64) {
 // phase moves the wave. Increasing phase from 0 to 1 will make the wave move out from the center a full cycle.
 // ramp will reduce the height of the ripple as it extends from the center to the radius so it smoothly blends into the image.
 func (c *Context) WarpRippleRadius(centerX, centerY, radius, spacing, offset, phase float64, ramp bool) {
-	s := NewSurface(c.Width, c.Height)
-	dstData, _ := ImageDataFromSurface(s)
-	srcData, _ := ImageDataFromSurface(c.Surface)
+	c.TryWarpRippleRadius(centerX, centerY, radius, spacing, offset, phase, ramp)
+}
+
+// TryWarpRippleRadius is WarpRippleRadius, returning an error if the pixel data cannot be read or written.
+func (c *Context) TryWarpRippleRadius(centerX, centerY, radius, spacing, offset, phase float64, ramp bool) error {
+	srcData, err := ImageDataFromSurface(c.Surface)
+	if err != nil {
+		return err
+	}
+	dstData := NewImageDataWithFormat(srcData.Format(), srcData.Width, srcData.Height)
 	rings := radius / spacing
 
 	for x := 0.0; x < c.Width; x++ {
@@ -754,5 +988,5 @@ func (c *Context) WarpRippleRadius(centerX, centerY, radius, spacing, offset, ph
 			dstData.SetPixel(int(x), int(y), r, g, b, a)
 		}
 	}
-	c.Surface.SetData(dstData.data)
+	return c.Surface.SetData(dstData.data)
 }
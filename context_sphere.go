@@ -66,6 +66,7 @@ func (s *Sphere) Draw(context *Context) {
 	grad.AddColorStopRGB(s.hlSize, s.r, s.g, s.b)
 	grad.AddColorStopRGB(1, s.shadowR, s.shadowG, s.shadowB)
 	context.SetSource(grad)
+	grad.Destroy()
 
 	context.FillCircle(0, 0, s.Radius)
 	context.Restore()
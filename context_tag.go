@@ -0,0 +1,76 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+// #include <cairo/cairo.h>
+// #include <stdlib.h>
+import "C"
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"unsafe"
+)
+
+// Tags add structure to PDF output, such as links and link destinations.
+// Other surfaces ignore them, so the same drawing code can render images and PDFs.
+
+// TagBegin starts a tag with the given name and attributes. Drawing up to the matching TagEnd is inside the tag.
+// See TagLink and TagDest for the tags cairo supports and their attributes.
+func (c *Context) TagBegin(tagName, attributes string) {
+	defer runtime.KeepAlive(c)
+	cname := C.CString(tagName)
+	defer C.free(unsafe.Pointer(cname))
+	cattributes := C.CString(attributes)
+	defer C.free(unsafe.Pointer(cattributes))
+	C.cairo_tag_begin(c.context, cname, cattributes)
+}
+
+// TagEnd ends the most recent tag with the given name.
+func (c *Context) TagEnd(tagName string) {
+	defer runtime.KeepAlive(c)
+	cname := C.CString(tagName)
+	defer C.free(unsafe.Pointer(cname))
+	C.cairo_tag_end(c.context, cname)
+}
+
+// tagString quotes a string for use as a tag attribute value.
+func tagString(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `'`, `\'`)
+	return "'" + value + "'"
+}
+
+// pageAttribute returns the attribute linking to a page, counting from 1.
+func pageAttribute(page int) string {
+	return fmt.Sprintf("page=%d", page)
+}
+
+// BeginLinkURI starts a link to an external uri. Everything drawn until EndLink is clickable.
+func (c *Context) BeginLinkURI(uri string) {
+	c.TagBegin(TagLink, "uri="+tagString(uri))
+}
+
+// BeginLinkDest starts a link to a named destination in the document. Everything drawn until EndLink is clickable.
+// See AddDestination.
+func (c *Context) BeginLinkDest(dest string) {
+	c.TagBegin(TagLink, "dest="+tagString(dest))
+}
+
+// BeginLinkPage starts a link to a page of the document, counting from 1. Everything drawn until EndLink is clickable.
+func (c *Context) BeginLinkPage(page int) {
+	c.TagBegin(TagLink, pageAttribute(page))
+}
+
+// EndLink ends the current link.
+func (c *Context) EndLink() {
+	c.TagEnd(TagLink)
+}
+
+// AddDestination adds a named destination at x, y on the current page, in user space,
+// that links and outline items can go to.
+func (c *Context) AddDestination(name string, x, y float64) {
+	x, y = c.UserToDevice(x, y)
+	c.TagBegin(TagDest, fmt.Sprintf("name=%s x=%f y=%f", tagString(name), x, y))
+	c.TagEnd(TagDest)
+}
@@ -2,6 +2,8 @@
 package cairo
 
 import (
+	"bytes"
+	"errors"
 	"math"
 	"testing"
 )
@@ -541,3 +543,367 @@ func TestFillPreserve(t *testing.T) {
 		t.Errorf("Expected pixel %d, %d to have alpha greater than 0, got %d\n", x, y, alpha)
 	}
 }
+
+func TestTextOnPath(t *testing.T) {
+	_, context := createContext()
+	context.SetFontSize(20)
+	path := &Path{}
+	path.MoveTo(50, 200)
+	path.LineTo(350, 200)
+
+	context.TextOnPath("hello", path, 0, TextAlignLeft)
+	status := context.GetStatus()
+	if status != StatusSuccess {
+		t.Errorf("Expected status %q, got %q\n", StatusSuccess, status)
+	}
+	left, _, right, bottom := context.PathExtents()
+	width := context.TextExtents("hello").Xadvance
+	if left < 50 || right > 50+width+1 {
+		t.Errorf("Expected text between 50 and %f, got %f to %f\n", 50+width, left, right)
+	}
+	if bottom > 210 {
+		t.Errorf("Expected text baseline near 200, got bottom of %f\n", bottom)
+	}
+
+	context.NewPath()
+	context.TextOnPath("hello", path, 0, TextAlignJustify)
+	left, _, right, _ = context.PathExtents()
+	if left > 60 || right < 340 {
+		t.Errorf("Expected justified text to span 50 to 350, got %f to %f\n", left, right)
+	}
+}
+
+func TestGlyphs(t *testing.T) {
+	_, context := createContext()
+	context.SelectFontFace("sans", FontSlantNormal, FontWeightNormal)
+	context.SetFontSize(20)
+
+	glyphs, err := context.TextToGlyphs(10, 50, "abc")
+	if err != nil {
+		t.Fatalf("Unable to convert text to glyphs. Error: %s\n", err)
+	}
+	if len(glyphs) != 3 {
+		t.Fatalf("Expected 3 glyphs, got %d\n", len(glyphs))
+	}
+	if glyphs[0].X != 10 || glyphs[0].Y != 50 {
+		t.Errorf("Expected first glyph at 10, 50, got %f, %f\n", glyphs[0].X, glyphs[0].Y)
+	}
+	if glyphs[1].X <= glyphs[0].X {
+		t.Errorf("Expected glyphs to advance, got %f then %f\n", glyphs[0].X, glyphs[1].X)
+	}
+
+	glyphExtents := context.GlyphExtents(glyphs)
+	textExtents := context.TextExtents("abc")
+	if math.Abs(glyphExtents.Width-textExtents.Width) > 0.001 {
+		t.Errorf("Expected glyph width %f to match text width %f\n", glyphExtents.Width, textExtents.Width)
+	}
+
+	fontExtents := context.FontExtents()
+	if fontExtents.Ascent <= 0 || fontExtents.Height <= 0 {
+		t.Errorf("Expected positive font extents, got %v\n", fontExtents)
+	}
+
+	context.ShowGlyphs(glyphs)
+	status := context.GetStatus()
+	if status != StatusSuccess {
+		t.Errorf("Expected status %q, got %q\n", StatusSuccess, status)
+	}
+}
+
+func TestFontFaceFromFile(t *testing.T) {
+	_, err := NewFontFaceFromFile("testdata/missing.ttf")
+	if err == nil {
+		t.Errorf("Expected error loading missing font file\n")
+	}
+
+	_, context := createContext()
+	face := NewToyFontFace("sans", FontSlantNormal, FontWeightBold)
+	context.SetFontFace(face)
+	current := context.GetFontFace()
+	if current.GetType() != FontTypeToy {
+		t.Errorf("Expected font type %v, got %v\n", FontTypeToy, current.GetType())
+	}
+	if err := current.Status(); err != nil {
+		t.Errorf("Expected no font face error, got %s\n", err)
+	}
+
+	// FontAwesome 4.7, SIL Open Font License 1.1. U+F0C8 is a filled square.
+	fileFace, err := NewFontFaceFromFile("testdata/FontAwesome.ttf")
+	if err != nil {
+		t.Fatalf("Unable to load font file. Error: %s\n", err)
+	}
+	defer fileFace.Destroy()
+	if fileFace.GetType() != FontTypeFT {
+		t.Errorf("Expected font type %v, got %v\n", FontTypeFT, fileFace.GetType())
+	}
+	if _, err := NewFontFaceFromFileIndex("testdata/FontAwesome.ttf", 1); err == nil {
+		t.Errorf("Expected error loading missing face index 1\n")
+	}
+	indexFace, err := NewFontFaceFromFileIndex("testdata/FontAwesome.ttf", 0)
+	if err != nil {
+		t.Fatalf("Unable to load font face index 0. Error: %s\n", err)
+	}
+	indexFace.Destroy()
+
+	surface, context := createContext()
+	context.SetFontFace(fileFace)
+	context.SetFontSize(40)
+	extents := context.TextExtents("\uf0c8")
+	if extents.Width <= 0 || extents.Height <= 0 || extents.Xadvance <= 0 {
+		t.Fatalf("Expected non zero text extents, got %v\n", extents)
+	}
+	context.SetSourceBlack()
+	context.FillText("\uf0c8", 100, 200)
+	if err := context.Err(); err != nil {
+		t.Fatalf("Unable to draw with font file. Error: %s\n", err)
+	}
+	data, err := surface.GetData()
+	if err != nil {
+		t.Fatalf("Unable to get surface data. Error: %s\n", err)
+	}
+	x := int(100 + extents.Xbearing + extents.Width/2)
+	y := int(200 + extents.Ybearing + extents.Height/2)
+	_, _, _, alpha := getPixel(data, x, y, 400)
+	if alpha != 255 {
+		t.Errorf("Expected ink at %d, %d, got alpha %d\n", x, y, alpha)
+	}
+}
+
+func TestLayoutText(t *testing.T) {
+	_, context := createContext()
+	context.SetFontSize(20)
+	wordWidth := context.TextExtents("word").Xadvance
+	spaceWidth := context.TextExtents(" ").Xadvance
+	// room for two words per line.
+	width := wordWidth*2 + spaceWidth*1.5
+
+	layout := context.LayoutText("word word word word word\nword", 10, 20, width, 0, 30, TextAlignJustify, VerticalAlignTop)
+	if len(layout.Lines) != 4 {
+		t.Fatalf("Expected 4 lines, got %d\n", len(layout.Lines))
+	}
+	if !layout.Lines[0].Justified || layout.Lines[0].Width != width {
+		t.Errorf("Expected first line to be justified to %f, got %f\n", width, layout.Lines[0].Width)
+	}
+	if layout.Lines[2].Justified {
+		t.Errorf("Expected last line of paragraph not to be justified\n")
+	}
+	bounds := layout.Bounds
+	if bounds.X != 10 || bounds.Y != 20 || bounds.Height != 120 {
+		t.Errorf("Expected bounds at 10, 20 with height 120, got %v\n", bounds)
+	}
+
+	layout = context.LayoutText("word", 0, 200, 100, 0, 30, TextAlignRight, VerticalAlignBottom)
+	if layout.Bounds.Y != 170 {
+		t.Errorf("Expected bottom aligned block to start at 170, got %f\n", layout.Bounds.Y)
+	}
+	if math.Abs(layout.Lines[0].X+wordWidth-100) > 0.001 {
+		t.Errorf("Expected right aligned line to end at 100, got %f\n", layout.Lines[0].X+wordWidth)
+	}
+}
+
+func TestFontOptions(t *testing.T) {
+	_, context := createContext()
+	options := NewFontOptions()
+	options.SetAntialias(AntialiasGray)
+	options.SetHintStyle(HintStyleNone)
+	options.SetHintMetrics(HintMetricsOff)
+	if err := options.Status(); err != nil {
+		t.Errorf("Expected no font options error, got %s\n", err)
+	}
+	context.SetFontOptions(options)
+
+	current := context.GetFontOptions()
+	if !current.Equal(options) {
+		t.Errorf("Expected context font options to equal the options set\n")
+	}
+	if current.GetHintStyle() != HintStyleNone {
+		t.Errorf("Expected hint style %v, got %v\n", HintStyleNone, current.GetHintStyle())
+	}
+	if current.GetHintMetrics() != HintMetricsOff {
+		t.Errorf("Expected hint metrics %v, got %v\n", HintMetricsOff, current.GetHintMetrics())
+	}
+	if current.GetAntialias() != AntialiasGray {
+		t.Errorf("Expected antialias %v, got %v\n", AntialiasGray, current.GetAntialias())
+	}
+}
+
+func TestFontFaceDestroyTwice(t *testing.T) {
+	_, context := createContext()
+	face := NewToyFontFace("sans-serif", FontSlantNormal, FontWeightNormal)
+	context.SetFontFace(face)
+	face.Destroy()
+	face.Destroy()
+
+	current := context.GetFontFace()
+	scaled := context.GetScaledFont()
+	current.Destroy()
+	current.Destroy()
+	scaled.Destroy()
+	scaled.Destroy()
+	if err := context.Err(); err != nil {
+		t.Errorf("Expected no context error after destroying fonts twice, got %s\n", err)
+	}
+	face = context.GetFontFace()
+	defer face.Destroy()
+	if face.GetType() != FontTypeToy {
+		t.Errorf("Expected context to keep its toy font face, got %v\n", face.GetType())
+	}
+}
+
+func TestUserFontFace(t *testing.T) {
+	_, context := createContext()
+	face, err := NewUserFontFace(func(c *Context, char rune) {
+		c.FillRectangle(0, -0.5, 0.4, 0.5)
+	}, func(char rune) float64 {
+		return 0.5
+	})
+	if err != nil {
+		t.Errorf("Expected no user font error, got %s\n", err)
+		return
+	}
+	defer face.Destroy()
+	if face.GetType() != FontTypeUser {
+		t.Errorf("Expected font type %v, got %v\n", FontTypeUser, face.GetType())
+	}
+
+	RegisterUserFontFace("blocks", FontSlantNormal, FontWeightBold, face)
+	defer func() {
+		if !UnregisterUserFontFace("blocks", FontSlantNormal, FontWeightBold) {
+			t.Errorf("Expected registered user font to be unregistered\n")
+		}
+		if getUserFontFace("blocks", FontSlantNormal, FontWeightBold) != nil {
+			t.Errorf("Expected no user font after unregistering\n")
+		}
+		if UnregisterUserFontFace("blocks", FontSlantNormal, FontWeightBold) {
+			t.Errorf("Expected unregistering twice to return false\n")
+		}
+	}()
+	context.SelectFontFace("blocks", FontSlantNormal, FontWeightBold)
+	context.SetFontSize(100)
+	extents := context.TextExtents("AB")
+	if math.Abs(extents.Xadvance-100) > 0.01 {
+		t.Errorf("Expected x advance 100, got %f\n", extents.Xadvance)
+	}
+	if math.Abs(extents.Width-90) > 1 || math.Abs(extents.Height-50) > 1 {
+		t.Errorf("Expected ink size 90 x 50, got %f x %f\n", extents.Width, extents.Height)
+	}
+}
+
+func TestUserFontFaceSurface(t *testing.T) {
+	_, context := createContext()
+	var dataErr error
+	face, err := NewUserFontFace(func(c *Context, char rune) {
+		_, dataErr = c.Surface.GetData()
+		c.Grayscale()
+		c.FillRectangle(0, -0.5, 0.4, 0.5)
+	}, nil)
+	if err != nil {
+		t.Errorf("Expected no user font error, got %s\n", err)
+		return
+	}
+	defer face.Destroy()
+	context.SetFontFace(face)
+	context.SetFontSize(100)
+	context.TextExtents("A")
+	if dataErr == nil {
+		t.Errorf("Expected an error reading pixel data in a user font glyph\n")
+	}
+	if err := context.Err(); err != nil {
+		t.Errorf("Expected no context error, got %s\n", err)
+	}
+}
+
+func TestContextErr(t *testing.T) {
+	_, context := createContext()
+	if err := context.Err(); err != nil {
+		t.Errorf("Expected no error on a new context, got %s\n", err)
+	}
+	err := context.TryPaintImage("testdata/missing.png", 0, 0)
+	if !errors.Is(err, StatusFileNotFound) {
+		t.Errorf("Expected error to match %v, got %v\n", StatusFileNotFound, err)
+	}
+
+	context.Restore()
+	err = context.Err()
+	if !errors.Is(err, StatusInvalidRestore) {
+		t.Errorf("Expected error to match %v, got %v\n", StatusInvalidRestore, err)
+	}
+	if errors.Is(err, StatusNoMemory) {
+		t.Errorf("Expected error not to match %v\n", StatusNoMemory)
+	}
+
+	recording, err := NewUnboundedRecordingSurface(ContentColorAlpha)
+	if err != nil {
+		t.Errorf("Expected no error creating recording surface, got %s\n", err)
+		return
+	}
+	defer recording.Destroy()
+	vector := NewContext(recording)
+	defer vector.Destroy()
+	if err := vector.TryGrayscale(); err == nil {
+		t.Errorf("Expected an error filtering a surface without pixel data\n")
+	}
+	if err := vector.TryBlurRect(0, 0, 10, 10, 2); err != nil {
+		t.Errorf("Expected no error filtering a rectangle of a recording surface, got %s\n", err)
+	}
+}
+
+func TestFiltersRGB24(t *testing.T) {
+	filters := map[string]func(*Context) error{
+		"Blur":         func(c *Context) error { return c.TryBlur(2) },
+		"GaussianBlur": func(c *Context) error { return c.TryGaussianBlur(2) },
+		"Sharpen":      func(c *Context) error { return c.TrySharpen() },
+	}
+	for name, filter := range filters {
+		surface := NewSurfaceWithFormat(FormatRGB24, 20, 20)
+		context := NewContext(surface)
+		// dark gray on the left, light gray on the right.
+		context.SetSourceRGB(0.25, 0.25, 0.25)
+		context.FillRectangle(0, 0, 10, 20)
+		context.SetSourceRGB(0.75, 0.75, 0.75)
+		context.FillRectangle(10, 0, 10, 20)
+		surface.Flush()
+		before, _, _, _ := surface.GetPixel(getData(surface, t), 9, 10)
+
+		if err := filter(context); err != nil {
+			t.Errorf("Expected no error running %s on an RGB24 surface, got %s\n", name, err)
+		}
+		after, _, _, _ := surface.GetPixel(getData(surface, t), 9, 10)
+		if after == before {
+			t.Errorf("Expected %s to change the pixel next to the edge, got %d before and after\n", name, after)
+		}
+		context.Destroy()
+		surface.Destroy()
+	}
+}
+
+func TestFiltersUnsupportedFormat(t *testing.T) {
+	filters := map[string]func(*Context) error{
+		"Grayscale":      func(c *Context) error { return c.TryGrayscale() },
+		"Threshold":      func(c *Context) error { return c.TryThreshold(0.5, 1, 0, 0, 1) },
+		"Invert":         func(c *Context) error { return c.TryInvert() },
+		"Tint":           func(c *Context) error { return c.TryTint(1, 0, 0, 0.5) },
+		"MapHue":         func(c *Context) error { return c.TryMapHue(0, 180) },
+		"ColorFringe":    func(c *Context) error { return c.TryColorFringe(2) },
+		"DitherAtkinson": func(c *Context) error { return c.TryDitherAtkinson() },
+		"FilterChannels": func(c *Context) error { return c.Surface.TryFilterChannels(1, 0, 0) },
+	}
+	for _, format := range []Format{FormatA8, FormatRGB16565} {
+		for name, filter := range filters {
+			surface := NewSurfaceWithFormat(format, 10, 10)
+			context := NewContext(surface)
+			context.SetSourceRGBA(0.5, 0.25, 0.75, 0.5)
+			context.FillRectangle(0, 0, 5, 10)
+			before := getData(surface, t)
+
+			if err := filter(context); err == nil {
+				t.Errorf("Expected an error running %s on a format %d surface\n", name, format)
+			}
+			if !bytes.Equal(before, getData(surface, t)) {
+				t.Errorf("Expected %s to leave a format %d surface unchanged\n", name, format)
+			}
+			context.Destroy()
+			surface.Destroy()
+		}
+	}
+}
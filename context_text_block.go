@@ -0,0 +1,139 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+import (
+	"math"
+	"strings"
+)
+
+// TextLine is a single laid out line of a text block.
+// X, Y is the start of the line's baseline. Width is the advance width of the line.
+// Spacing is the width used between each word, which is wider than a space for justified lines.
+type TextLine struct {
+	Text      string
+	Words     []string
+	X, Y      float64
+	Width     float64
+	Spacing   float64
+	Justified bool
+}
+
+// TextLayout is a block of text laid out into lines.
+// Bounds is the area covered by the lines, using the line height for each line.
+type TextLayout struct {
+	Lines  []TextLine
+	Bounds Rectangle
+}
+
+// LayoutText wraps text into lines no wider than width, using the current font, without drawing anything.
+// Lines are broken on spaces and newlines. A single word wider than width gets a line of its own.
+// Lines are aligned horizontally within x and width. Justified lines are spread to fill the width, except the last line of each paragraph.
+// The block is aligned vertically within y and height. A height of 0 aligns the block's top, middle or bottom on y.
+func (c *Context) LayoutText(text string, x, y, width, height, lineHeight float64, align TextAlign, valign VerticalAlign) *TextLayout {
+	spaceWidth := c.TextExtents(" ").Xadvance
+	layout := &TextLayout{}
+
+	for _, paragraph := range strings.Split(text, "\n") {
+		words := strings.Fields(paragraph)
+		lines := []TextLine{}
+		current := TextLine{}
+		for _, word := range words {
+			candidate := word
+			if len(current.Words) > 0 {
+				candidate = current.Text + " " + word
+			}
+			candidateWidth := c.TextExtents(candidate).Xadvance
+			if candidateWidth > width && len(current.Words) > 0 {
+				lines = append(lines, current)
+				candidate = word
+				candidateWidth = c.TextExtents(word).Xadvance
+				current = TextLine{}
+			}
+			current.Text = candidate
+			current.Words = append(current.Words, word)
+			current.Width = candidateWidth
+		}
+		lines = append(lines, current)
+
+		for i := range lines {
+			line := &lines[i]
+			line.X = x
+			line.Spacing = spaceWidth
+			switch align {
+			case TextAlignCenter:
+				line.X = x + (width-line.Width)/2
+			case TextAlignRight:
+				line.X = x + width - line.Width
+			case TextAlignJustify:
+				if i < len(lines)-1 && len(line.Words) > 1 {
+					line.Spacing += (width - line.Width) / float64(len(line.Words)-1)
+					line.Width = width
+					line.Justified = true
+				}
+			}
+		}
+		layout.Lines = append(layout.Lines, lines...)
+	}
+
+	blockHeight := lineHeight * float64(len(layout.Lines))
+	top := y
+	switch valign {
+	case VerticalAlignMiddle:
+		top = y + (height-blockHeight)/2
+	case VerticalAlignBottom:
+		top = y + height - blockHeight
+	}
+
+	// center the font's ascent and descent within each line.
+	fontExtents := c.FontExtents()
+	baseline := (lineHeight + fontExtents.Ascent - fontExtents.Descent) / 2
+
+	left, right := math.Inf(1), math.Inf(-1)
+	for i := range layout.Lines {
+		line := &layout.Lines[i]
+		line.Y = top + float64(i)*lineHeight + baseline
+		left = math.Min(left, line.X)
+		right = math.Max(right, line.X+line.Width)
+	}
+	layout.Bounds = Rectangle{left, top, right - left, blockHeight}
+	return layout
+}
+
+// TextLayoutPath adds the outlines of the laid out text to the current path.
+func (c *Context) TextLayoutPath(layout *TextLayout) {
+	for _, line := range layout.Lines {
+		if !line.Justified {
+			c.MoveTo(line.X, line.Y)
+			c.TextPath(line.Text)
+			continue
+		}
+		x := line.X
+		for _, word := range line.Words {
+			c.MoveTo(x, line.Y)
+			c.TextPath(word)
+			x += c.TextExtents(word).Xadvance + line.Spacing
+		}
+	}
+}
+
+// TextBlock lays out text with LayoutText and adds it to the current path, returning the bounds of the block.
+// Follow with Fill or Stroke, or use FillTextBlock or StrokeTextBlock.
+func (c *Context) TextBlock(text string, x, y, width, height, lineHeight float64, align TextAlign, valign VerticalAlign) Rectangle {
+	layout := c.LayoutText(text, x, y, width, height, lineHeight, align, valign)
+	c.TextLayoutPath(layout)
+	return layout.Bounds
+}
+
+// FillTextBlock draws a block of wrapped text and fills it, returning the bounds of the block.
+func (c *Context) FillTextBlock(text string, x, y, width, height, lineHeight float64, align TextAlign, valign VerticalAlign) Rectangle {
+	bounds := c.TextBlock(text, x, y, width, height, lineHeight, align, valign)
+	c.Fill()
+	return bounds
+}
+
+// StrokeTextBlock draws a block of wrapped text and strokes it, returning the bounds of the block.
+func (c *Context) StrokeTextBlock(text string, x, y, width, height, lineHeight float64, align TextAlign, valign VerticalAlign) Rectangle {
+	bounds := c.TextBlock(text, x, y, width, height, lineHeight, align, valign)
+	c.Stroke()
+	return bounds
+}
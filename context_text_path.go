@@ -0,0 +1,58 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+import "unicode/utf8"
+
+// TextOnPath adds the outlines of text to the current path, with each glyph laid out along the given path.
+// Each glyph is rotated to follow the tangent of the path, with its baseline on the path.
+// align determines whether the text starts at the start of the path, is centered on it, or ends at the end of it.
+// TextAlignJustify spreads the glyphs evenly so the text runs from the start of the path to its end.
+// Text that is already longer than the path, or has a single glyph, is left aligned.
+// offset then moves the text further along the path (or back, if negative).
+// Glyphs that fall outside the path are skipped.
+// Follow with Fill or Stroke, or use FillTextOnPath or StrokeTextOnPath.
+func (c *Context) TextOnPath(text string, path *Path, offset float64, align TextAlign) {
+	pm := NewPathMeasure(path)
+	width := c.TextExtents(text).Xadvance
+	distance := offset
+	spacing := 0.0
+	switch align {
+	case TextAlignCenter:
+		distance += (pm.Length() - width) / 2
+	case TextAlignRight:
+		distance += pm.Length() - width
+	case TextAlignJustify:
+		if count := utf8.RuneCountInString(text); count > 1 && width < pm.Length() {
+			spacing = (pm.Length() - width) / float64(count-1)
+		}
+	}
+
+	for _, char := range text {
+		glyph := string(char)
+		advance := c.TextExtents(glyph).Xadvance
+		mid := distance + advance/2
+		distance += advance + spacing
+		if mid < 0 || mid > pm.Length() {
+			continue
+		}
+		x, y, angle := pm.PointAtDistance(mid)
+		c.Save()
+		c.Translate(x, y)
+		c.Rotate(angle)
+		c.MoveTo(-advance/2, 0)
+		c.TextPath(glyph)
+		c.Restore()
+	}
+}
+
+// FillTextOnPath lays out text along a path and fills it.
+func (c *Context) FillTextOnPath(text string, path *Path, offset float64, align TextAlign) {
+	c.TextOnPath(text, path, offset, align)
+	c.Fill()
+}
+
+// StrokeTextOnPath lays out text along a path and strokes it.
+func (c *Context) StrokeTextOnPath(text string, path *Path, offset float64, align TextAlign) {
+	c.TextOnPath(text, path, offset, align)
+	c.Stroke()
+}
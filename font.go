@@ -0,0 +1,241 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+// #include <cairo/cairo.h>
+// #include <stdlib.h>
+import "C"
+
+import "unsafe"
+
+// FontFace represents a cairo_font_face_t
+type FontFace struct {
+	fontFace *C.cairo_font_face_t
+	// whether this value owns a reference, released by Destroy.
+	owned bool
+}
+
+// ScaledFont represents a cairo_scaled_font_t, a font face at a particular size and transformation.
+type ScaledFont struct {
+	scaledFont *C.cairo_scaled_font_t
+	// whether this value owns a reference, released by Destroy.
+	owned bool
+}
+
+// newFontFace wraps a native font face, taking ownership of its reference.
+func newFontFace(native *C.cairo_font_face_t) *FontFace {
+	return &FontFace{fontFace: native, owned: true}
+}
+
+// newScaledFont wraps a native scaled font, taking ownership of its reference.
+func newScaledFont(native *C.cairo_scaled_font_t) *ScaledFont {
+	return &ScaledFont{scaledFont: native, owned: true}
+}
+
+////////////////////////////
+// conversion helpers
+
+// newTextExtents converts a native cairo_text_extents_t.
+func newTextExtents(cte C.cairo_text_extents_t) *TextExtents {
+	return &TextExtents{
+		Xbearing: float64(cte.x_bearing),
+		Ybearing: float64(cte.y_bearing),
+		Width:    float64(cte.width),
+		Height:   float64(cte.height),
+		Xadvance: float64(cte.x_advance),
+		Yadvance: float64(cte.y_advance),
+	}
+}
+
+// newFontExtents converts a native cairo_font_extents_t.
+func newFontExtents(cfe C.cairo_font_extents_t) *FontExtents {
+	return &FontExtents{
+		Ascent:      float64(cfe.ascent),
+		Descent:     float64(cfe.descent),
+		Height:      float64(cfe.height),
+		MaxXadvance: float64(cfe.max_x_advance),
+		MaxYadvance: float64(cfe.max_y_advance),
+	}
+}
+
+// glyphsToNative converts a slice of glyphs to native glyphs.
+func glyphsToNative(glyphs []Glyph) []C.cairo_glyph_t {
+	cglyphs := make([]C.cairo_glyph_t, len(glyphs))
+	for i, glyph := range glyphs {
+		cglyphs[i].index = C.ulong(glyph.Index)
+		cglyphs[i].x = C.double(glyph.X)
+		cglyphs[i].y = C.double(glyph.Y)
+	}
+	return cglyphs
+}
+
+// glyphsPtr returns a pointer to the first native glyph, or nil for no glyphs.
+func glyphsPtr(cglyphs []C.cairo_glyph_t) *C.cairo_glyph_t {
+	if len(cglyphs) == 0 {
+		return nil
+	}
+	return &cglyphs[0]
+}
+
+////////////////////////////
+// font face
+
+// NewToyFontFace creates a font face from a family name, slant and weight, the same as SelectFontFace uses.
+func NewToyFontFace(family string, fontSlant, fontWeight int) *FontFace {
+	cs := C.CString(family)
+	defer C.free(unsafe.Pointer(cs))
+	return newFontFace(C.cairo_toy_font_face_create(cs, C.cairo_font_slant_t(fontSlant), C.cairo_font_weight_t(fontWeight)))
+}
+
+// Status returns an error if the font face is in an error state.
+func (f *FontFace) Status() error {
+	status := Status(C.cairo_font_face_status(f.fontFace))
+	if status != StatusSuccess {
+		return status
+	}
+	return nil
+}
+
+// GetType returns the type of the backend used to create the font face.
+func (f *FontFace) GetType() FontType {
+	return FontType(C.cairo_font_face_get_type(f.fontFace))
+}
+
+// GetReferenceCount gets the number of objects that are holding a reference to this font face.
+func (f *FontFace) GetReferenceCount() int {
+	return int(C.cairo_font_face_get_reference_count(f.fontFace))
+}
+
+// Destroy releases the reference owned by this font face, freeing it when no other references exist.
+// It is safe to call more than once.
+func (f *FontFace) Destroy() {
+	if !f.owned {
+		return
+	}
+	f.owned = false
+	C.cairo_font_face_destroy(f.fontFace)
+}
+
+////////////////////////////
+// scaled font
+
+// NewScaledFont creates a scaled font from a font face.
+// fontMatrix maps font space to user space, usually a scale of the font size.
+// ctm is the user to device transformation the font will be used with.
+func NewScaledFont(fontFace *FontFace, fontMatrix, ctm *Matrix) *ScaledFont {
+	options := NewFontOptions()
+	defer options.Destroy()
+	return NewScaledFontWithOptions(fontFace, fontMatrix, ctm, options)
+}
+
+// NewScaledFontWithOptions creates a scaled font from a font face, using the given font options.
+func NewScaledFontWithOptions(fontFace *FontFace, fontMatrix, ctm *Matrix, options *FontOptions) *ScaledFont {
+	return newScaledFont(C.cairo_scaled_font_create(fontFace.fontFace, fontMatrix.Native(), ctm.Native(), options.fontOptions))
+}
+
+// Status returns an error if the scaled font is in an error state.
+func (s *ScaledFont) Status() error {
+	status := Status(C.cairo_scaled_font_status(s.scaledFont))
+	if status != StatusSuccess {
+		return status
+	}
+	return nil
+}
+
+// GetType returns the type of the backend used to create the scaled font.
+func (s *ScaledFont) GetType() FontType {
+	return FontType(C.cairo_scaled_font_get_type(s.scaledFont))
+}
+
+// GetReferenceCount gets the number of objects that are holding a reference to this scaled font.
+func (s *ScaledFont) GetReferenceCount() int {
+	return int(C.cairo_scaled_font_get_reference_count(s.scaledFont))
+}
+
+// Destroy releases the reference owned by this scaled font, freeing it when no other references exist.
+// It is safe to call more than once.
+func (s *ScaledFont) Destroy() {
+	if !s.owned {
+		return
+	}
+	s.owned = false
+	C.cairo_scaled_font_destroy(s.scaledFont)
+}
+
+// GetFontFace returns the font face this scaled font was created from.
+func (s *ScaledFont) GetFontFace() *FontFace {
+	return newFontFace(C.cairo_font_face_reference(C.cairo_scaled_font_get_font_face(s.scaledFont)))
+}
+
+// GetFontMatrix returns the font matrix this scaled font was created with.
+func (s *ScaledFont) GetFontMatrix() *Matrix {
+	matrix := &Matrix{}
+	C.cairo_scaled_font_get_font_matrix(s.scaledFont, matrix.Native())
+	return matrix
+}
+
+// GetCTM returns the ctm this scaled font was created with.
+func (s *ScaledFont) GetCTM() *Matrix {
+	matrix := &Matrix{}
+	C.cairo_scaled_font_get_ctm(s.scaledFont, matrix.Native())
+	return matrix
+}
+
+// GetScaleMatrix returns the font matrix multiplied by the ctm.
+func (s *ScaledFont) GetScaleMatrix() *Matrix {
+	matrix := &Matrix{}
+	C.cairo_scaled_font_get_scale_matrix(s.scaledFont, matrix.Native())
+	return matrix
+}
+
+// GetFontOptions returns a copy of the font options this scaled font was created with.
+// Call Destroy on it when done.
+func (s *ScaledFont) GetFontOptions() *FontOptions {
+	options := NewFontOptions()
+	C.cairo_scaled_font_get_font_options(s.scaledFont, options.fontOptions)
+	return options
+}
+
+// Extents returns the font extents for this scaled font.
+func (s *ScaledFont) Extents() *FontExtents {
+	cfe := C.cairo_font_extents_t{}
+	C.cairo_scaled_font_extents(s.scaledFont, &cfe)
+	return newFontExtents(cfe)
+}
+
+// TextExtents returns the extents of the given text in this scaled font.
+func (s *ScaledFont) TextExtents(text string) *TextExtents {
+	cte := C.cairo_text_extents_t{}
+	cs := C.CString(text)
+	defer C.free(unsafe.Pointer(cs))
+	C.cairo_scaled_font_text_extents(s.scaledFont, cs, &cte)
+	return newTextExtents(cte)
+}
+
+// GlyphExtents returns the extents of the given glyphs in this scaled font.
+func (s *ScaledFont) GlyphExtents(glyphs []Glyph) *TextExtents {
+	cte := C.cairo_text_extents_t{}
+	cglyphs := glyphsToNative(glyphs)
+	C.cairo_scaled_font_glyph_extents(s.scaledFont, glyphsPtr(cglyphs), C.int(len(cglyphs)), &cte)
+	return newTextExtents(cte)
+}
+
+// TextToGlyphs converts text to a slice of glyphs positioned with the font's advances, starting at x, y.
+func (s *ScaledFont) TextToGlyphs(x, y float64, text string) ([]Glyph, error) {
+	cs := C.CString(text)
+	defer C.free(unsafe.Pointer(cs))
+
+	var cglyphs *C.cairo_glyph_t
+	var numGlyphs C.int
+	status := Status(C.cairo_scaled_font_text_to_glyphs(s.scaledFont, C.double(x), C.double(y),
+		cs, C.int(len(text)), &cglyphs, &numGlyphs, nil, nil, nil))
+	if status != StatusSuccess {
+		return nil, status
+	}
+	defer C.cairo_glyph_free(cglyphs)
+
+	glyphs := make([]Glyph, int(numGlyphs))
+	for i, cglyph := range unsafe.Slice(cglyphs, int(numGlyphs)) {
+		glyphs[i] = Glyph{uint64(cglyph.index), float64(cglyph.x), float64(cglyph.y)}
+	}
+	return glyphs, nil
+}
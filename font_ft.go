@@ -0,0 +1,86 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+// #cgo pkg-config: cairo-ft
+// #include <cairo/cairo-ft.h>
+// #include <ft2build.h>
+// #include FT_FREETYPE_H
+// #include <stdlib.h>
+// extern void blcairoFTDoneFace(void *face);
+import "C"
+
+import (
+	"fmt"
+	"sync"
+	"unsafe"
+)
+
+// freetype library and face creation and release are not thread safe.
+// ftMutex guards ftLibrary and every call that creates or releases a face.
+var (
+	ftMutex   sync.Mutex
+	ftLibrary C.FT_Library
+)
+
+// ftFaceKey is the user data key under which a font face keeps its FT_Face.
+const ftFaceKey = "blcairo.ftface"
+
+//export blcairoFTDoneFace
+func blcairoFTDoneFace(face unsafe.Pointer) {
+	ftMutex.Lock()
+	defer ftMutex.Unlock()
+	C.FT_Done_Face(C.FT_Face(face))
+}
+
+// newFTFace loads the face at index in a font file, starting freetype if needed.
+func newFTFace(path string, index int) (C.FT_Face, error) {
+	cs := C.CString(path)
+	defer C.free(unsafe.Pointer(cs))
+
+	ftMutex.Lock()
+	defer ftMutex.Unlock()
+
+	if ftLibrary == nil {
+		if ftError := C.FT_Init_FreeType(&ftLibrary); ftError != 0 {
+			ftLibrary = nil
+			return nil, fmt.Errorf("unable to start freetype: freetype error %d", int(ftError))
+		}
+	}
+	var face C.FT_Face
+	if ftError := C.FT_New_Face(ftLibrary, cs, C.FT_Long(index), &face); ftError != 0 {
+		return nil, fmt.Errorf("unable to load font face %q: freetype error %d", path, int(ftError))
+	}
+	return face, nil
+}
+
+// NewFontFaceFromFile creates a font face from a font file such as a .ttf or .otf, using FreeType.
+// Fonts loaded this way render identically on any machine, unlike fonts selected by name with SelectFontFace.
+// Set it on a context with SetFontFace.
+func NewFontFaceFromFile(path string) (*FontFace, error) {
+	return NewFontFaceFromFileIndex(path, 0)
+}
+
+// NewFontFaceFromFileIndex creates a font face from the face at the given index in a font file.
+// This is used for font collections such as .ttc files, which contain more than one face.
+func NewFontFaceFromFileIndex(path string, index int) (*FontFace, error) {
+	ftFace, err := newFTFace(path, index)
+	if err != nil {
+		return nil, err
+	}
+
+	// the FT_Face is released when cairo destroys the font face.
+	face := newFontFace(C.cairo_ft_font_face_create_for_ft_face(ftFace, 0))
+	if err := face.Status(); err != nil {
+		C.cairo_font_face_destroy(face.fontFace)
+		blcairoFTDoneFace(unsafe.Pointer(ftFace))
+		return nil, fmt.Errorf("unable to load font face %q: %w", path, err)
+	}
+	status := Status(C.cairo_font_face_set_user_data(face.fontFace, userDataKey(ftFaceKey), unsafe.Pointer(ftFace),
+		C.cairo_destroy_func_t(C.blcairoFTDoneFace)))
+	if status != StatusSuccess {
+		C.cairo_font_face_destroy(face.fontFace)
+		blcairoFTDoneFace(unsafe.Pointer(ftFace))
+		return nil, fmt.Errorf("unable to load font face %q: %w", path, status)
+	}
+	return face, nil
+}
@@ -0,0 +1,132 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+// #include <cairo/cairo.h>
+// #include <stdlib.h>
+import "C"
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+// FontOptions represents a cairo_font_options_t, which controls how fonts are rendered.
+type FontOptions struct {
+	fontOptions *C.cairo_font_options_t
+}
+
+// NewFontOptions creates a new FontOptions with all options set to default values.
+func NewFontOptions() *FontOptions {
+	return &FontOptions{C.cairo_font_options_create()}
+}
+
+// Copy creates a new FontOptions with the same values as this one.
+func (f *FontOptions) Copy() *FontOptions {
+	return &FontOptions{C.cairo_font_options_copy(f.fontOptions)}
+}
+
+// Destroy destroys the font options, freeing memory.
+func (f *FontOptions) Destroy() {
+	C.cairo_font_options_destroy(f.fontOptions)
+}
+
+// Status returns an error if the font options are in an error state.
+func (f *FontOptions) Status() error {
+	status := Status(C.cairo_font_options_status(f.fontOptions))
+	if status != StatusSuccess {
+		return status
+	}
+	return nil
+}
+
+// Merge merges non-default options from other into this FontOptions.
+func (f *FontOptions) Merge(other *FontOptions) {
+	C.cairo_font_options_merge(f.fontOptions, other.fontOptions)
+}
+
+// Equal returns whether all options in this FontOptions are the same as in other.
+func (f *FontOptions) Equal(other *FontOptions) bool {
+	return C.cairo_font_options_equal(f.fontOptions, other.fontOptions) != 0
+}
+
+// Hash returns a hash value for the font options.
+func (f *FontOptions) Hash() uint64 {
+	return uint64(C.cairo_font_options_hash(f.fontOptions))
+}
+
+// SetAntialias sets the antialias mode used when rendering text.
+func (f *FontOptions) SetAntialias(antialias Antialias) {
+	C.cairo_font_options_set_antialias(f.fontOptions, C.cairo_antialias_t(antialias))
+}
+
+// GetAntialias returns the antialias mode used when rendering text.
+func (f *FontOptions) GetAntialias() Antialias {
+	return Antialias(C.cairo_font_options_get_antialias(f.fontOptions))
+}
+
+// SetSubpixelOrder sets the order of color elements within each pixel, used with AntialiasSubpixel.
+func (f *FontOptions) SetSubpixelOrder(order SubpixelOrder) {
+	C.cairo_font_options_set_subpixel_order(f.fontOptions, C.cairo_subpixel_order_t(order))
+}
+
+// GetSubpixelOrder returns the order of color elements within each pixel.
+func (f *FontOptions) GetSubpixelOrder() SubpixelOrder {
+	return SubpixelOrder(C.cairo_font_options_get_subpixel_order(f.fontOptions))
+}
+
+// SetHintStyle sets how strongly glyph outlines are fitted to the pixel grid.
+func (f *FontOptions) SetHintStyle(style HintStyle) {
+	C.cairo_font_options_set_hint_style(f.fontOptions, C.cairo_hint_style_t(style))
+}
+
+// GetHintStyle returns how strongly glyph outlines are fitted to the pixel grid.
+func (f *FontOptions) GetHintStyle() HintStyle {
+	return HintStyle(C.cairo_font_options_get_hint_style(f.fontOptions))
+}
+
+// SetHintMetrics sets whether font metrics are rounded to integer values.
+func (f *FontOptions) SetHintMetrics(metrics HintMetrics) {
+	C.cairo_font_options_set_hint_metrics(f.fontOptions, C.cairo_hint_metrics_t(metrics))
+}
+
+// GetHintMetrics returns whether font metrics are rounded to integer values.
+func (f *FontOptions) GetHintMetrics() HintMetrics {
+	return HintMetrics(C.cairo_font_options_get_hint_metrics(f.fontOptions))
+}
+
+// SetVariations sets OpenType font variations, such as "wght=700,wdth=80".
+func (f *FontOptions) SetVariations(variations string) {
+	cs := C.CString(variations)
+	defer C.free(unsafe.Pointer(cs))
+	C.cairo_font_options_set_variations(f.fontOptions, cs)
+}
+
+// GetVariations returns the OpenType font variations.
+func (f *FontOptions) GetVariations() string {
+	return C.GoString(C.cairo_font_options_get_variations(f.fontOptions))
+}
+
+// SetFontOptions sets the font options used when rendering text in this context.
+func (c *Context) SetFontOptions(options *FontOptions) {
+	defer runtime.KeepAlive(c)
+	C.cairo_set_font_options(c.context, options.fontOptions)
+}
+
+// GetFontOptions returns a copy of the font options set on this context.
+// Call Destroy on it when done.
+func (c *Context) GetFontOptions() *FontOptions {
+	defer runtime.KeepAlive(c)
+	options := NewFontOptions()
+	C.cairo_get_font_options(c.context, options.fontOptions)
+	return options
+}
+
+// DisableFontHinting turns off hinting of glyph outlines and metrics.
+// Hinted text jumps between pixels as it moves or scales, which shows up as shimmering in animations.
+func (c *Context) DisableFontHinting() {
+	options := c.GetFontOptions()
+	defer options.Destroy()
+	options.SetHintStyle(HintStyleNone)
+	options.SetHintMetrics(HintMetricsOff)
+	c.SetFontOptions(options)
+}
@@ -0,0 +1,154 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+// #include <cairo/cairo.h>
+// #include <stdlib.h>
+// #include <stdint.h>
+// extern cairo_status_t blcairoUserFontInit(cairo_scaled_font_t *scaled_font, cairo_t *cr, cairo_font_extents_t *extents);
+// extern cairo_status_t blcairoUserFontRenderGlyph(cairo_scaled_font_t *scaled_font, unsigned long glyph, cairo_t *cr, cairo_text_extents_t *extents);
+// extern void blcairoDeleteHandle(void *data);
+import "C"
+
+import (
+	"errors"
+	"sync"
+)
+
+// userFontKey is the user data key under which a user font face stores its Go callbacks.
+const userFontKey = "blcairo.userfont"
+
+// userFont holds the Go callbacks for a user font face.
+type userFont struct {
+	render  func(*Context, rune)
+	advance func(rune) float64
+}
+
+// user font glyphs are drawn in a unit em square. These are the font extents reported to cairo.
+const (
+	userFontAscent  = 0.8
+	userFontDescent = 0.2
+)
+
+//export blcairoUserFontInit
+func blcairoUserFontInit(scaledFont *C.cairo_scaled_font_t, cr *C.cairo_t, extents *C.cairo_font_extents_t) C.cairo_status_t {
+	extents.ascent = userFontAscent
+	extents.descent = userFontDescent
+	extents.height = userFontAscent + userFontDescent
+	extents.max_x_advance = 1
+	extents.max_y_advance = 0
+	return C.cairo_status_t(StatusSuccess)
+}
+
+//export blcairoUserFontRenderGlyph
+func blcairoUserFontRenderGlyph(scaledFont *C.cairo_scaled_font_t, glyph C.ulong, cr *C.cairo_t, extents *C.cairo_text_extents_t) (status C.cairo_status_t) {
+	data := C.cairo_font_face_get_user_data(C.cairo_scaled_font_get_font_face(scaledFont), userDataKey(userFontKey))
+	if data == nil {
+		return C.cairo_status_t(StatusUserFontError)
+	}
+	uf := handleDataValue(data).(*userFont)
+
+	// a panic must not unwind through cairo.
+	defer func() {
+		if recover() != nil {
+			status = C.cairo_status_t(StatusUserFontError)
+		}
+	}()
+
+	char := rune(glyph)
+	// the target is the recording surface cairo draws the glyph into. It belongs to cairo.
+	target := &Surface{surface: C.cairo_get_target(cr)}
+	context := &Context{context: cr, Surface: target, Width: 1, Height: 1}
+	uf.render(context, char)
+	extents.x_advance = C.double(uf.advance(char))
+	return C.cairo_status_t(StatusSuccess)
+}
+
+// NewUserFontFace creates a font face whose glyphs are drawn by the render function.
+// Each glyph is drawn in a unit em square with the baseline at y = 0 and y increasing downward,
+// so a capital letter spans roughly y = -0.7 to 0. The drawing is scaled by the font size.
+// The Context passed to render has a Width and Height of 1 em. Its Surface is a vector recording surface,
+// so methods that read or write pixel data, such as the filters and PaintImage, fail or leave it unchanged.
+// advance returns how far to move right after each glyph, in ems. If it is nil every glyph advances 0.6 em.
+// Use the font face with SetFontFace, or register it with RegisterUserFontFace to use it with SelectFontFace.
+func NewUserFontFace(render func(*Context, rune), advance func(rune) float64) (*FontFace, error) {
+	if render == nil {
+		return nil, errors.New("user font render function is nil")
+	}
+	if advance == nil {
+		advance = func(rune) float64 { return 0.6 }
+	}
+	fontFace := C.cairo_user_font_face_create()
+	face := newFontFace(fontFace)
+	if err := face.Status(); err != nil {
+		return nil, err
+	}
+	C.cairo_user_font_face_set_init_func(fontFace,
+		C.cairo_user_scaled_font_init_func_t(C.blcairoUserFontInit))
+	C.cairo_user_font_face_set_render_glyph_func(fontFace,
+		C.cairo_user_scaled_font_render_glyph_func_t(C.blcairoUserFontRenderGlyph))
+
+	data := newHandleData(&userFont{render, advance})
+	status := Status(C.cairo_font_face_set_user_data(fontFace, userDataKey(userFontKey), data,
+		C.cairo_destroy_func_t(C.blcairoDeleteHandle)))
+	if status != StatusSuccess {
+		blcairoDeleteHandle(data)
+		face.Destroy()
+		return nil, status
+	}
+	return face, nil
+}
+
+////////////////////
+// Registry
+////////////////////
+
+// userFontID identifies a registered user font face.
+type userFontID struct {
+	family string
+	slant  int
+	weight int
+}
+
+var (
+	userFontsMutex sync.RWMutex
+	userFonts      = map[userFontID]*FontFace{}
+)
+
+// RegisterUserFontFace makes a font face available to SelectFontFace under the given family, slant and weight,
+// using the FontSlant and FontWeight constants. This allows a family to have separate faces for bold and italic.
+// Registering the same family, slant and weight again replaces the previous face. UnregisterUserFontFace removes it.
+func RegisterUserFontFace(family string, fontSlant, fontWeight int, fontFace *FontFace) {
+	registered := newFontFace(C.cairo_font_face_reference(fontFace.fontFace))
+	id := userFontID{family, fontSlant, fontWeight}
+
+	userFontsMutex.Lock()
+	defer userFontsMutex.Unlock()
+	if old, ok := userFonts[id]; ok {
+		old.Destroy()
+	}
+	userFonts[id] = registered
+}
+
+// UnregisterUserFontFace removes the font face registered under the given family, slant and weight,
+// releasing the registry's reference to it. It returns false if no face was registered.
+// Contexts that already selected the face keep using it.
+func UnregisterUserFontFace(family string, fontSlant, fontWeight int) bool {
+	id := userFontID{family, fontSlant, fontWeight}
+
+	userFontsMutex.Lock()
+	defer userFontsMutex.Unlock()
+	fontFace, ok := userFonts[id]
+	if !ok {
+		return false
+	}
+	delete(userFonts, id)
+	fontFace.Destroy()
+	return true
+}
+
+// getUserFontFace returns the registered font face for a family, slant and weight, or nil.
+func getUserFontFace(family string, fontSlant, fontWeight int) *FontFace {
+	userFontsMutex.RLock()
+	defer userFontsMutex.RUnlock()
+	return userFonts[userFontID{family, fontSlant, fontWeight}]
+}
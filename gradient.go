@@ -0,0 +1,258 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+import (
+	"math"
+
+	"github.com/bit101/bitlib/blcolor"
+	"github.com/bit101/bitlib/blmath"
+)
+
+// ColorSpace is the color space that gradient colors are interpolated in.
+type ColorSpace int
+
+// ColorSpace constants
+const (
+	// ColorSpaceSRGB interpolates the raw rgb values, as cairo does between stops.
+	ColorSpaceSRGB ColorSpace = iota
+	// ColorSpaceLinearRGB interpolates light intensity, avoiding dark bands between saturated colors.
+	ColorSpaceLinearRGB
+	// ColorSpaceHSV interpolates hue, saturation and value, taking the shortest way around the hue circle.
+	ColorSpaceHSV
+	// ColorSpaceOKLab interpolates in a perceptually uniform space, giving even, clean transitions.
+	ColorSpaceOKLab
+)
+
+// String returns the name of the color space.
+func (c ColorSpace) String() string {
+	switch c {
+	case ColorSpaceSRGB:
+		return "srgb"
+	case ColorSpaceLinearRGB:
+		return "linear rgb"
+	case ColorSpaceHSV:
+		return "hsv"
+	case ColorSpaceOKLab:
+		return "oklab"
+	}
+	return "unknown"
+}
+
+// gradientSteps is the number of stops inserted between each pair of colors
+// when interpolating in a color space other than sRGB.
+const gradientSteps = 16
+
+////////////////////
+// Interpolation
+////////////////////
+
+// InterpolateColor returns the color t of the way from colorA to colorB, interpolated in the given color space.
+// Alpha is always interpolated linearly.
+func InterpolateColor(colorA, colorB blcolor.Color, t float64, space ColorSpace) blcolor.Color {
+	alpha := blmath.Lerp(t, colorA.A, colorB.A)
+	var c blcolor.Color
+	switch space {
+	case ColorSpaceLinearRGB:
+		c = blcolor.RGB(
+			linearToSRGB(blmath.Lerp(t, sRGBToLinear(colorA.R), sRGBToLinear(colorB.R))),
+			linearToSRGB(blmath.Lerp(t, sRGBToLinear(colorA.G), sRGBToLinear(colorB.G))),
+			linearToSRGB(blmath.Lerp(t, sRGBToLinear(colorA.B), sRGBToLinear(colorB.B))),
+		)
+	case ColorSpaceHSV:
+		h0, s0, v0 := colorA.ToHSV()
+		h1, s1, v1 := colorB.ToHSV()
+		// ToHSV returns saturation and value as 0-100.
+		s0, v0, s1, v1 = s0/100, v0/100, s1/100, v1/100
+		// a grey has no hue, so take the hue of the other color.
+		if s0 == 0 {
+			h0 = h1
+		}
+		if s1 == 0 {
+			h1 = h0
+		}
+		dh := math.Mod(h1-h0+540, 360) - 180
+		c = blcolor.HSV(h0+dh*t, blmath.Lerp(t, s0, s1), blmath.Lerp(t, v0, v1))
+	case ColorSpaceOKLab:
+		l0, a0, b0 := toOKLab(colorA)
+		l1, a1, b1 := toOKLab(colorB)
+		c = fromOKLab(blmath.Lerp(t, l0, l1), blmath.Lerp(t, a0, a1), blmath.Lerp(t, b0, b1))
+	default:
+		c = blcolor.RGB(
+			blmath.Lerp(t, colorA.R, colorB.R),
+			blmath.Lerp(t, colorA.G, colorB.G),
+			blmath.Lerp(t, colorA.B, colorB.B),
+		)
+	}
+	c.A = alpha
+	return c
+}
+
+// colorAt returns the color at t (0-1) along a list of evenly spaced colors.
+func colorAt(colors []blcolor.Color, t float64, space ColorSpace) blcolor.Color {
+	if len(colors) == 1 {
+		return colors[0]
+	}
+	t = blmath.Clamp(t, 0, 1) * float64(len(colors)-1)
+	index := int(t)
+	if index >= len(colors)-1 {
+		return colors[len(colors)-1]
+	}
+	return InterpolateColor(colors[index], colors[index+1], t-float64(index), space)
+}
+
+// paletteColors returns the colors in a palette as a slice.
+func paletteColors(palette *blcolor.Palette) []blcolor.Color {
+	colors := make([]blcolor.Color, palette.Size())
+	for i := range colors {
+		colors[i] = palette.Get(i)
+	}
+	return colors
+}
+
+func sRGBToLinear(value float64) float64 {
+	if value <= 0.04045 {
+		return value / 12.92
+	}
+	return math.Pow((value+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(value float64) float64 {
+	if value <= 0.0031308 {
+		return blmath.Clamp(value*12.92, 0, 1)
+	}
+	return blmath.Clamp(1.055*math.Pow(value, 1/2.4)-0.055, 0, 1)
+}
+
+// toOKLab converts a color to OKLab lightness and a, b components.
+func toOKLab(color blcolor.Color) (float64, float64, float64) {
+	r, g, b := sRGBToLinear(color.R), sRGBToLinear(color.G), sRGBToLinear(color.B)
+	l := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*b)
+	m := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*b)
+	s := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*b)
+	return 0.2104542553*l + 0.7936177850*m - 0.0040720468*s,
+		1.9779984951*l - 2.4285922050*m + 0.4505937099*s,
+		0.0259040371*l + 0.7827717662*m - 0.8086757660*s
+}
+
+// fromOKLab converts OKLab lightness and a, b components to a color.
+func fromOKLab(lightness, a, b float64) blcolor.Color {
+	l := lightness + 0.3963377774*a + 0.2158037573*b
+	m := lightness - 0.1055613458*a - 0.0638541728*b
+	s := lightness - 0.0894841775*a - 1.2914855480*b
+	l, m, s = l*l*l, m*m*m, s*s*s
+	return blcolor.RGB(
+		linearToSRGB(4.0767416621*l-3.3077115913*m+0.2309699292*s),
+		linearToSRGB(-1.2684380046*l+2.6097574011*m-0.3413193965*s),
+		linearToSRGB(-0.0041960863*l-0.7034186147*m+1.7076147010*s),
+	)
+}
+
+////////////////////
+// Gradient stops
+////////////////////
+
+// AddColorStop adds a color stop to a gradient pattern.
+func (p *Pattern) AddColorStop(offset float64, color blcolor.Color) {
+	p.AddColorStopRGBA(offset, color.R, color.G, color.B, color.A)
+}
+
+// AddColorStops adds evenly spaced color stops to a gradient pattern, from offset 0 to 1.
+// For color spaces other than ColorSpaceSRGB, intermediate stops are added so the gradient follows that space.
+func (p *Pattern) AddColorStops(colors []blcolor.Color, space ColorSpace) {
+	if len(colors) == 0 {
+		return
+	}
+	if len(colors) == 1 {
+		p.AddColorStop(0, colors[0])
+		return
+	}
+	steps := gradientSteps
+	if space == ColorSpaceSRGB {
+		steps = 1
+	}
+	count := (len(colors) - 1) * steps
+	for i := 0; i <= count; i++ {
+		t := float64(i) / float64(count)
+		p.AddColorStop(t, colorAt(colors, t, space))
+	}
+}
+
+// AddPaletteStops adds evenly spaced color stops for each color in the palette to a gradient pattern.
+func (p *Pattern) AddPaletteStops(palette *blcolor.Palette, space ColorSpace) {
+	p.AddColorStops(paletteColors(palette), space)
+}
+
+////////////////////
+// Gradient creation
+////////////////////
+
+// CreateLinearGradientColors creates a linear gradient through the given colors, interpolated in the given color space.
+func CreateLinearGradientColors(x0, y0, x1, y1 float64, colors []blcolor.Color, space ColorSpace) *Pattern {
+	p := CreateLinearGradient(x0, y0, x1, y1)
+	p.AddColorStops(colors, space)
+	return p
+}
+
+// CreateRadialGradientColors creates a radial gradient through the given colors, interpolated in the given color space.
+func CreateRadialGradientColors(cx0, cy0, radius0, cx1, cy1, radius1 float64, colors []blcolor.Color, space ColorSpace) *Pattern {
+	p := CreateRadialGradient(cx0, cy0, radius0, cx1, cy1, radius1)
+	p.AddColorStops(colors, space)
+	return p
+}
+
+// CreateConicGradientColors creates an angular gradient that sweeps through the given colors
+// around cx, cy, starting at startAngle and going clockwise. It is a full circle CreateConicSweep reaching out to radius.
+// To get a seamless loop, end the colors with the first color.
+func CreateConicGradientColors(cx, cy, radius, startAngle float64, colors []blcolor.Color, space ColorSpace) *Pattern {
+	return CreateConicSweep(cx, cy, 0, radius, startAngle, startAngle+blmath.TwoPi, colors, space)
+}
+
+// conicSegmentAngle is the largest angle covered by a single conic mesh patch.
+const conicSegmentAngle = math.Pi / 16
+
+// addConicPatches adds mesh patches filling the ring between two radii from angle a0 to a1.
+// colorFunc gives the color for t from 0 to 1 along the sweep.
+// An inner radius of 0 makes a pie shape, with each patch's inner edge collapsed to the center.
+func (p *Pattern) addConicPatches(cx, cy, innerRadius, outerRadius, a0, a1 float64, colorFunc func(t float64) blcolor.Color) {
+	sweep := a1 - a0
+	count := int(math.Ceil(math.Abs(sweep) / conicSegmentAngle))
+	if count < 1 {
+		count = 1
+	}
+	step := sweep / float64(count)
+	// control point distance for approximating an arc of angle step with a bezier curve.
+	k := 4.0 / 3.0 * math.Tan(step/4)
+
+	for i := 0; i < count; i++ {
+		t0 := float64(i) / float64(count)
+		t1 := float64(i+1) / float64(count)
+		start := a0 + step*float64(i)
+		end := start + step
+		cos0, sin0 := math.Cos(start), math.Sin(start)
+		cos1, sin1 := math.Cos(end), math.Sin(end)
+		c0 := colorFunc(t0)
+		c1 := colorFunc(t1)
+
+		p.BeginPatch()
+		p.MoveTo(cx+cos0*innerRadius, cy+sin0*innerRadius)
+		p.LineTo(cx+cos0*outerRadius, cy+sin0*outerRadius)
+		p.CurveTo(
+			cx+(cos0-k*sin0)*outerRadius, cy+(sin0+k*cos0)*outerRadius,
+			cx+(cos1+k*sin1)*outerRadius, cy+(sin1-k*cos1)*outerRadius,
+			cx+cos1*outerRadius, cy+sin1*outerRadius,
+		)
+		p.LineTo(cx+cos1*innerRadius, cy+sin1*innerRadius)
+		if innerRadius > 0 {
+			p.CurveTo(
+				cx+(cos1+k*sin1)*innerRadius, cy+(sin1-k*cos1)*innerRadius,
+				cx+(cos0-k*sin0)*innerRadius, cy+(sin0+k*cos0)*innerRadius,
+				cx+cos0*innerRadius, cy+sin0*innerRadius,
+			)
+		}
+		p.SetCornerColor(0, c0)
+		p.SetCornerColor(1, c0)
+		p.SetCornerColor(2, c1)
+		p.SetCornerColor(3, c1)
+		p.EndPatch()
+	}
+}
@@ -4,32 +4,60 @@ package cairo
 import (
 	"errors"
 	"fmt"
+	"image"
+	"image/color"
 )
 
 // ImageData holds the pixel data from a surface.
 type ImageData struct {
 	data   []byte
+	format Format
+	stride int
 	Width  int
 	Height int
 }
 
-// NewImageData creates a new empty ImageData with the given width and height.
+// NewImageData creates a new empty ARGB32 ImageData with the given width and height.
 func NewImageData(w, h int) ImageData {
+	return NewImageDataWithFormat(FormatARGB32, w, h)
+}
+
+// NewImageDataWithFormat creates a new empty ImageData with the given pixel format, width and height.
+// It can be copied to a surface created by NewSurfaceWithFormat with the same format and size.
+func NewImageDataWithFormat(format Format, w, h int) ImageData {
+	stride := format.StrideForWidth(w)
 	return ImageData{
-		data:   make([]byte, w*h*4),
+		data:   make([]byte, stride*h),
+		format: format,
+		stride: stride,
 		Width:  w,
 		Height: h,
 	}
 }
 
+// Format returns the pixel format of the ImageData.
+func (b *ImageData) Format() Format {
+	return b.format
+}
+
+// getStride returns the stride of the data, defaulting to ARGB32 rows for a zero value ImageData.
+func (b *ImageData) getStride() int {
+	if b.stride == 0 {
+		return b.Width * 4
+	}
+	return b.stride
+}
+
 // ImageDataFromSurface creates a new ImageData from a surface.
 func ImageDataFromSurface(surface *Surface) (ImageData, error) {
 	data, err := surface.GetData()
 	if err != nil {
-		return ImageData{}, fmt.Errorf("unable to create ImageData: %s", err)
+		return ImageData{}, fmt.Errorf("unable to create ImageData: %w", err)
 	}
 	return ImageData{
 		data:   data,
+		format: surface.GetFormat(),
+		stride: surface.GetStride(),
 		Width:  surface.GetWidth(),
 		Height: surface.GetHeight(),
 	}, nil
@@ -39,8 +67,9 @@ func ImageDataFromSurface(surface *Surface) (ImageData, error) {
 func ImageDataFromPNG(filePath string) (ImageData, error) {
 	surface, err := NewSurfaceFromPNG(filePath)
 	if err != nil {
-		return ImageData{}, fmt.Errorf("unable to create surface for ImageData: %s", err)
+		return ImageData{}, fmt.Errorf("unable to create surface for ImageData: %w", err)
 	}
+	defer surface.Destroy()
 	return ImageDataFromSurface(surface)
 }
 
@@ -50,12 +79,8 @@ func (b *ImageData) GetPixel(x, y int) (float64, float64, float64, float64) {
 	if x < 0 || x >= b.Width || y < 0 || y >= b.Height {
 		return 0, 0, 0, 1
 	}
-	index := (y*b.Width + x) * 4
-	blue := float64(b.data[index])
-	green := float64(b.data[index+1])
-	red := float64(b.data[index+2])
-	alpha := float64(b.data[index+3])
-	return red / 255.0, green / 255.0, blue / 255.0, alpha / 255.0
+	red, green, blue, alpha := readPixel(b.data, b.format, b.getStride(), x, y)
+	return float64(red) / 255.0, float64(green) / 255.0, float64(blue) / 255.0, float64(alpha) / 255.0
 }
 
 // GetPixelClamped gets the value for a single pixel.
@@ -83,8 +108,8 @@ func (b *ImageData) GetPixelInt(x, y int) (int, int, int, int) {
 	if x < 0 || x >= b.Width || y < 0 || y >= b.Height {
 		return 0, 0, 0, 0
 	}
-	index := (y*b.Width + x) * 4
-	return int(b.data[index+2]), int(b.data[index+1]), int(b.data[index]), int(b.data[index+3])
+	red, green, blue, alpha := readPixel(b.data, b.format, b.getStride(), x, y)
+	return int(red), int(green), int(blue), int(alpha)
 }
 
 // GetPixelIntClamped gets the value for a single pixel.
@@ -112,11 +137,8 @@ func (b *ImageData) SetPixel(x, y int, red, green, blue, alpha float64) {
 	if x < 0 || x >= b.Width || y < 0 || y >= b.Height {
 		return
 	}
-	index := (y*b.Width + x) * 4
-	b.data[index] = byte(blue * 255.0)
-	b.data[index+1] = byte(green * 255.0)
-	b.data[index+2] = byte(red * 255.0)
-	b.data[index+3] = byte(alpha * 255.0)
+	writePixel(b.data, b.format, b.getStride(), x, y,
+		byte(red*255.0), byte(green*255.0), byte(blue*255.0), byte(alpha*255.0))
 }
 
 // SetPixelInt sets the value for a single pixel.
@@ -125,11 +147,7 @@ func (b *ImageData) SetPixelInt(x, y int, red, green, blue, alpha int) {
 	if x < 0 || x >= b.Width || y < 0 || y >= b.Height {
 		return
 	}
-	index := (y*b.Width + x) * 4
-	b.data[index] = byte(blue)
-	b.data[index+1] = byte(green)
-	b.data[index+2] = byte(red)
-	b.data[index+3] = byte(alpha)
+	writePixel(b.data, b.format, b.getStride(), x, y, byte(red), byte(green), byte(blue), byte(alpha))
 }
 
 // CopyToSurface copies this ImageData's data into a surface
@@ -147,9 +165,41 @@ func (b *ImageData) CopyToSurface(surface *Surface) error {
 	if surface.GetWidth() != b.Width || surface.GetHeight() != b.Height {
 		return errors.New("surface must have same dimensions as the imagedata")
 	}
+	if surface.GetFormat() != b.format {
+		return errors.New("surface must have same format as the imagedata")
+	}
 	err := surface.SetData(b.data)
 	if err != nil {
-		return fmt.Errorf("unable to copy ImageData: %s", err)
+		return fmt.Errorf("unable to copy ImageData: %w", err)
 	}
 	return nil
 }
+
+// ColorModel returns the color model of the ImageData, implementing image.Image.
+// Pixel data taken from a cairo surface is alpha-premultiplied, so this is color.RGBAModel.
+func (b *ImageData) ColorModel() color.Model {
+	return color.RGBAModel
+}
+
+// Bounds returns the bounds of the ImageData, implementing image.Image.
+func (b *ImageData) Bounds() image.Rectangle {
+	return image.Rect(0, 0, b.Width, b.Height)
+}
+
+// At returns the color of a single pixel, implementing image.Image.
+func (b *ImageData) At(x, y int) color.Color {
+	if x < 0 || x >= b.Width || y < 0 || y >= b.Height {
+		return color.RGBA{}
+	}
+	red, green, blue, alpha := readPixel(b.data, b.format, b.getStride(), x, y)
+	return color.RGBA{red, green, blue, alpha}
+}
+
+// Set sets the color of a single pixel, implementing draw.Image.
+func (b *ImageData) Set(x, y int, c color.Color) {
+	if x < 0 || x >= b.Width || y < 0 || y >= b.Height {
+		return
+	}
+	rgba := color.RGBAModel.Convert(c).(color.RGBA)
+	writePixel(b.data, b.format, b.getStride(), x, y, rgba.R, rgba.G, rgba.B, rgba.A)
+}
@@ -0,0 +1,46 @@
+//go:build leakcheck
+
+// Package cairo wraps the c cairographics library.
+package cairo
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// leakcheckBuild is true in builds with the leakcheck tag.
+const leakcheckBuild = true
+
+// creation stacks of live owned values, recorded in leakcheck builds.
+var (
+	liveObjectsMutex sync.Mutex
+	liveObjectStacks = map[any]string{}
+)
+
+func trackObject(object any) {
+	liveObjectsMutex.Lock()
+	defer liveObjectsMutex.Unlock()
+	liveObjectStacks[object] = string(debug.Stack())
+}
+
+func untrackObject(object any) {
+	liveObjectsMutex.Lock()
+	defer liveObjectsMutex.Unlock()
+	delete(liveObjectStacks, object)
+}
+
+// LeakReport describes each owned surface, context, pattern and region that has not been destroyed,
+// with the stack where it was created.
+func LeakReport() string {
+	liveObjectsMutex.Lock()
+	defer liveObjectsMutex.Unlock()
+	reports := []string{}
+	for object, stack := range liveObjectStacks {
+		reports = append(reports, fmt.Sprintf("%T created at:\n%s", object, stack))
+	}
+	sort.Strings(reports)
+	return strings.Join(reports, "\n")
+}
@@ -0,0 +1,68 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+// #include <cairo/cairo.h>
+import "C"
+
+import (
+	"runtime"
+	"sync/atomic"
+)
+
+// Surface, Context, Pattern and Region values returned by constructors such as NewSurface, NewContext,
+// CreateLinearGradient, PopGroup and NewRegion own a cairo reference, which is released by Destroy.
+// Values returned by getters such as GetSource and GetGroupTarget borrow the context's reference,
+// and Destroy on them does nothing.
+//
+// An owned value that becomes unreachable without being destroyed is released by a finalizer,
+// so a missed Destroy does not leak. The garbage collector does not see the native memory behind a value,
+// so Destroy is still the way to free large surfaces promptly, such as in a render loop.
+// Methods keep their values reachable with runtime.KeepAlive until cairo is done with them,
+// so a finalizer cannot release an object while it is in use.
+// After Destroy a value must not be used, unless another reference keeps the cairo object alive.
+// In leakcheck builds the leak tracker keeps live values reachable, so finalizers never run
+// and every value that is not destroyed is reported by LeakReport.
+
+// counts of owned values that have not been destroyed.
+var liveSurfaces, liveContexts, livePatterns, liveRegions atomic.Int64
+
+// LiveObjects returns the number of owned surfaces, contexts, patterns and regions that have not been destroyed.
+// Comparing it before and after some code shows whether that code leaks.
+// Build with the leakcheck tag to also record where each live value was created, reported by LeakReport.
+func LiveObjects() (surfaces, contexts, patterns, regions int) {
+	return int(liveSurfaces.Load()), int(liveContexts.Load()), int(livePatterns.Load()), int(liveRegions.Load())
+}
+
+// newSurface wraps a native surface, taking ownership of its reference.
+// width and height are only used for surfaces that are not image surfaces.
+func newSurface(native *C.cairo_surface_t, width, height float64) *Surface {
+	s := &Surface{surface: native, pageWidth: width, pageHeight: height, owned: true}
+	liveSurfaces.Add(1)
+	trackObject(s)
+	runtime.SetFinalizer(s, (*Surface).Destroy)
+	return s
+}
+
+// newContext wraps a native context, taking ownership of its reference.
+func newContext(native *C.cairo_t, surface *Surface, width, height float64) *Context {
+	c := &Context{
+		context: native,
+		Surface: surface,
+		Width:   width,
+		Height:  height,
+		owned:   true,
+	}
+	liveContexts.Add(1)
+	trackObject(c)
+	runtime.SetFinalizer(c, (*Context).Destroy)
+	return c
+}
+
+// newPattern wraps a native pattern, taking ownership of its reference.
+func newPattern(native *C.cairo_pattern_t) *Pattern {
+	p := &Pattern{pattern: native, owned: true}
+	livePatterns.Add(1)
+	trackObject(p)
+	runtime.SetFinalizer(p, (*Pattern).Destroy)
+	return p
+}
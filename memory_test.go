@@ -0,0 +1,84 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/bit101/bitlib/blcolor"
+)
+
+func TestNoLeaks(t *testing.T) {
+	surfaces, contexts, patterns, regions := LiveObjects()
+
+	surface := NewSurface(100, 100)
+	context := NewContext(surface)
+	context.ClearWhite()
+	context.GrayscaleRect(10, 10, 50, 50)
+	context.BlurRect(10, 10, 50, 50, 2)
+	context.ColorFringe(2)
+	context.WarpNoise(1, 2, 0, 50, 50, 0)
+
+	context.PushGroup()
+	context.FillCircle(50, 50, 20)
+	group := context.PopGroup()
+	context.SetSource(group)
+	context.Paint()
+	group.Destroy()
+	context.GetSource().Destroy()
+
+	pattern, err := CreateHatchPattern(10, 1, 0.5, blcolor.RGB(0, 0, 0))
+	if err != nil {
+		t.Errorf("Expected no error creating hatch pattern, got %s\n", err)
+	} else {
+		pattern.Destroy()
+	}
+
+	region := NewRegionFromRectangle(Rectangle{0, 0, 10, 10})
+	region.Reference().Destroy()
+	region.Destroy()
+	region.Destroy()
+
+	reference := surface.Reference()
+	if surface.GetReferenceCount() != 3 {
+		t.Errorf("Expected surface reference count 3, got %d\n", surface.GetReferenceCount())
+	}
+	reference.Destroy()
+	context.Destroy()
+	context.Destroy()
+	surface.Destroy()
+
+	s, c, p, r := LiveObjects()
+	if s != surfaces || c != contexts || p != patterns || r != regions {
+		t.Errorf("Expected no leaked objects, got %d surfaces, %d contexts, %d patterns, %d regions\n%s\n",
+			s-surfaces, c-contexts, p-patterns, r-regions, LeakReport())
+	}
+}
+
+func TestFinalizers(t *testing.T) {
+	if leakcheckBuild {
+		t.Skip("the leak tracker keeps live values reachable")
+	}
+	surfaces, contexts, patterns, regions := LiveObjects()
+	func() {
+		surface := NewSurface(100, 100)
+		context := NewContext(surface)
+		context.SetSource(CreateLinearGradient(0, 0, 100, 0))
+		context.Paint()
+		NewRegion()
+	}()
+
+	// finalizers run after a collection, and a context keeps its surface reachable until it is finalized.
+	for i := 0; i < 100; i++ {
+		runtime.GC()
+		s, c, p, r := LiveObjects()
+		if s == surfaces && c == contexts && p == patterns && r == regions {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	s, c, p, r := LiveObjects()
+	t.Errorf("Expected unreachable objects to be released, got %d surfaces, %d contexts, %d patterns, %d regions\n",
+		s-surfaces, c-contexts, p-patterns, r-regions)
+}
@@ -0,0 +1,173 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+// #include <cairo/cairo.h>
+// #include <stdlib.h>
+import "C"
+
+import (
+	"runtime"
+	"unsafe"
+
+	"github.com/bit101/bitlib/geom"
+)
+
+// PathSegment is a single segment of a path.
+// MoveTo and LineTo segments have one point, CurveTo segments have three (two control points and an end point),
+// and ClosePath segments have none.
+type PathSegment struct {
+	Type   PathDataType
+	Points []*geom.Point
+}
+
+// Path is a Go representation of a cairo_path_t.
+// A zero Path is an empty path ready to use.
+type Path struct {
+	Segments []PathSegment
+}
+
+// pathDataHeader mirrors the header member of the cairo_path_data_t union.
+type pathDataHeader struct {
+	dataType C.cairo_path_data_type_t
+	length   C.int
+}
+
+// pathDataPoint mirrors the point member of the cairo_path_data_t union.
+type pathDataPoint struct {
+	x, y C.double
+}
+
+// newPathFromNative creates a Path from a native cairo_path_t and destroys the native path.
+func newPathFromNative(pathNative *C.cairo_path_t) (*Path, error) {
+	defer C.cairo_path_destroy(pathNative)
+	status := Status(pathNative.status)
+	if status != StatusSuccess {
+		return nil, status
+	}
+
+	path := &Path{}
+	data := unsafe.Slice(pathNative.data, int(pathNative.num_data))
+	for i := 0; i < len(data); {
+		header := (*pathDataHeader)(unsafe.Pointer(&data[i]))
+		length := int(header.length)
+		segment := PathSegment{Type: PathDataType(header.dataType)}
+		for j := 1; j < length; j++ {
+			point := (*pathDataPoint)(unsafe.Pointer(&data[i+j]))
+			segment.Points = append(segment.Points, geom.NewPoint(float64(point.x), float64(point.y)))
+		}
+		path.Segments = append(path.Segments, segment)
+		i += length
+	}
+	return path, nil
+}
+
+// native creates a native cairo_path_t from this path. The returned free func must be called when done.
+func (p *Path) native() (*C.cairo_path_t, func()) {
+	numData := 0
+	for _, segment := range p.Segments {
+		numData += len(segment.Points) + 1
+	}
+	pathNative := (*C.cairo_path_t)(C.calloc(1, C.size_t(unsafe.Sizeof(C.cairo_path_t{}))))
+	pathNative.status = C.cairo_status_t(StatusSuccess)
+	pathNative.num_data = C.int(numData)
+	if numData > 0 {
+		pathNative.data = (*C.cairo_path_data_t)(C.calloc(C.size_t(numData), C.size_t(unsafe.Sizeof(C.cairo_path_data_t{}))))
+	}
+
+	data := unsafe.Slice(pathNative.data, numData)
+	i := 0
+	for _, segment := range p.Segments {
+		header := (*pathDataHeader)(unsafe.Pointer(&data[i]))
+		header.dataType = C.cairo_path_data_type_t(segment.Type)
+		header.length = C.int(len(segment.Points) + 1)
+		for j, pt := range segment.Points {
+			point := (*pathDataPoint)(unsafe.Pointer(&data[i+j+1]))
+			point.x = C.double(pt.X)
+			point.y = C.double(pt.Y)
+		}
+		i += len(segment.Points) + 1
+	}
+
+	return pathNative, func() {
+		C.free(unsafe.Pointer(pathNative.data))
+		C.free(unsafe.Pointer(pathNative))
+	}
+}
+
+// MoveTo adds a MoveTo segment to the path.
+func (p *Path) MoveTo(x, y float64) {
+	p.Segments = append(p.Segments, PathSegment{PathMoveTo, []*geom.Point{geom.NewPoint(x, y)}})
+}
+
+// LineTo adds a LineTo segment to the path.
+func (p *Path) LineTo(x, y float64) {
+	p.Segments = append(p.Segments, PathSegment{PathLineTo, []*geom.Point{geom.NewPoint(x, y)}})
+}
+
+// CurveTo adds a Bezier CurveTo segment to the path.
+func (p *Path) CurveTo(x1, y1, x2, y2, x3, y3 float64) {
+	p.Segments = append(p.Segments, PathSegment{PathCurveTo, []*geom.Point{
+		geom.NewPoint(x1, y1),
+		geom.NewPoint(x2, y2),
+		geom.NewPoint(x3, y3),
+	}})
+}
+
+// ClosePath adds a ClosePath segment to the path.
+func (p *Path) ClosePath() {
+	p.Segments = append(p.Segments, PathSegment{PathClosePath, nil})
+}
+
+// Len returns the number of segments in the path.
+func (p *Path) Len() int {
+	return len(p.Segments)
+}
+
+// Points returns all the points in the path, including curve control points, in order.
+func (p *Path) Points() geom.PointList {
+	points := geom.PointList{}
+	for _, segment := range p.Segments {
+		points = append(points, segment.Points...)
+	}
+	return points
+}
+
+// Clone returns a deep copy of the path.
+func (p *Path) Clone() *Path {
+	path := &Path{Segments: make([]PathSegment, len(p.Segments))}
+	for i, segment := range p.Segments {
+		path.Segments[i].Type = segment.Type
+		for _, point := range segment.Points {
+			path.Segments[i].Points = append(path.Segments[i].Points, point.Clone())
+		}
+	}
+	return path
+}
+
+// EachSegment calls segmentFunc for every segment in the path, in order.
+func (p *Path) EachSegment(segmentFunc func(segment PathSegment)) {
+	for _, segment := range p.Segments {
+		segmentFunc(segment)
+	}
+}
+
+// CopyPath returns a copy of the current path, in user space.
+func (c *Context) CopyPath() (*Path, error) {
+	defer runtime.KeepAlive(c)
+	return newPathFromNative(C.cairo_copy_path(c.context))
+}
+
+// CopyPathFlat returns a copy of the current path, in user space, with all curves flattened to line segments.
+// The accuracy of the flattening is controlled by SetTolerance.
+func (c *Context) CopyPathFlat() (*Path, error) {
+	defer runtime.KeepAlive(c)
+	return newPathFromNative(C.cairo_copy_path_flat(c.context))
+}
+
+// AppendPath appends the given path to the current path.
+func (c *Context) AppendPath(path *Path) {
+	defer runtime.KeepAlive(c)
+	pathNative, free := path.native()
+	defer free()
+	C.cairo_append_path(c.context, pathNative)
+}
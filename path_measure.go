@@ -0,0 +1,178 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+import (
+	"math"
+	"sort"
+
+	"github.com/bit101/bitlib/geom"
+)
+
+// Flatten returns a new path with all curves replaced by lines.
+// tolerance is the maximum distance allowed between the curve and the lines approximating it.
+func (p *Path) Flatten(tolerance float64) *Path {
+	if tolerance <= 0 {
+		tolerance = 0.1
+	}
+	path := &Path{}
+	var start, current *geom.Point
+	for _, segment := range p.Segments {
+		switch segment.Type {
+		case PathMoveTo:
+			start, current = segment.Points[0], segment.Points[0]
+			path.MoveTo(current.X, current.Y)
+		case PathLineTo:
+			current = segment.Points[0]
+			path.LineTo(current.X, current.Y)
+		case PathCurveTo:
+			if current == nil {
+				start, current = segment.Points[0], segment.Points[0]
+				path.MoveTo(current.X, current.Y)
+			}
+			flattenCurve(path, current, segment.Points[0], segment.Points[1], segment.Points[2], tolerance, 0)
+			current = segment.Points[2]
+		case PathClosePath:
+			path.ClosePath()
+			current = start
+		}
+	}
+	return path
+}
+
+// flattenCurve recursively splits a curve until it is within tolerance of a straight line, adding lines to path.
+func flattenCurve(path *Path, p0, p1, p2, p3 *geom.Point, tolerance float64, depth int) {
+	// distance of the control points from the chord measures flatness.
+	dx, dy := p3.X-p0.X, p3.Y-p0.Y
+	chordSq := dx*dx + dy*dy
+	flat := false
+	if chordSq < 1e-12 {
+		flat = p0.Distance(p1) <= tolerance && p0.Distance(p2) <= tolerance
+	} else {
+		d1 := math.Abs((p1.X-p3.X)*dy - (p1.Y-p3.Y)*dx)
+		d2 := math.Abs((p2.X-p3.X)*dy - (p2.Y-p3.Y)*dx)
+		flat = (d1+d2)*(d1+d2) <= tolerance*tolerance*chordSq
+	}
+	if flat || depth >= 16 {
+		path.LineTo(p3.X, p3.Y)
+		return
+	}
+	left, right := splitCurve(0.5, p0, p1, p2, p3)
+	flattenCurve(path, left[0], left[1], left[2], left[3], tolerance, depth+1)
+	flattenCurve(path, right[0], right[1], right[2], right[3], tolerance, depth+1)
+}
+
+// measureLine is a single straight piece of a measured path.
+type measureLine struct {
+	x0, y0, x1, y1 float64
+	start, length  float64
+}
+
+// PathMeasure measures distances along a path.
+// It can find the point and tangent angle at any distance along the path.
+// Separate sub paths are measured as if they were joined end to end.
+type PathMeasure struct {
+	lines  []measureLine
+	length float64
+	startX float64
+	startY float64
+}
+
+// NewPathMeasure creates a new PathMeasure for the given path.
+// Any curves in the path are flattened first.
+func NewPathMeasure(path *Path) *PathMeasure {
+	pm := &PathMeasure{}
+	var start, current *geom.Point
+	add := func(p0, p1 *geom.Point) {
+		length := p0.Distance(p1)
+		if length == 0 {
+			return
+		}
+		pm.lines = append(pm.lines, measureLine{p0.X, p0.Y, p1.X, p1.Y, pm.length, length})
+		pm.length += length
+	}
+	for _, segment := range path.Flatten(0.1).Segments {
+		switch segment.Type {
+		case PathMoveTo:
+			start, current = segment.Points[0], segment.Points[0]
+		case PathLineTo:
+			if current == nil {
+				start, current = segment.Points[0], segment.Points[0]
+				continue
+			}
+			add(current, segment.Points[0])
+			current = segment.Points[0]
+		case PathClosePath:
+			if current != nil {
+				add(current, start)
+			}
+			current = start
+		}
+		if pm.length == 0 && start != nil {
+			pm.startX, pm.startY = start.X, start.Y
+		}
+	}
+	return pm
+}
+
+// Length returns the total length of the path.
+func (pm *PathMeasure) Length() float64 {
+	return pm.length
+}
+
+// PointAtDistance returns the x, y point and the tangent angle at the given distance along the path.
+// Distances less than 0 or greater than the length of the path are clamped to the ends of the path.
+func (pm *PathMeasure) PointAtDistance(distance float64) (float64, float64, float64) {
+	if len(pm.lines) == 0 {
+		return pm.startX, pm.startY, 0
+	}
+	distance = math.Max(0, math.Min(distance, pm.length))
+	index := sort.Search(len(pm.lines), func(i int) bool {
+		return pm.lines[i].start+pm.lines[i].length >= distance
+	})
+	if index == len(pm.lines) {
+		index--
+	}
+	line := pm.lines[index]
+	t := (distance - line.start) / line.length
+	x := line.x0 + (line.x1-line.x0)*t
+	y := line.y0 + (line.y1-line.y0)*t
+	return x, y, math.Atan2(line.y1-line.y0, line.x1-line.x0)
+}
+
+// PointAtT returns the x, y point and the tangent angle at a normalized position along the path.
+// A t of 0 is the start of the path and 1 is the end of the path.
+func (pm *PathMeasure) PointAtT(t float64) (float64, float64, float64) {
+	return pm.PointAtDistance(t * pm.length)
+}
+
+// MeasurePath creates a PathMeasure for the current path, flattened by cairo.
+func (c *Context) MeasurePath() (*PathMeasure, error) {
+	path, err := c.CopyPathFlat()
+	if err != nil {
+		return nil, err
+	}
+	return NewPathMeasure(path), nil
+}
+
+// DrawArrowHead draws the point of an arrow at x, y, pointing in the direction of angle.
+func (c *Context) DrawArrowHead(x, y, angle, pointSize float64) {
+	c.Save()
+	c.Translate(x, y)
+	c.Rotate(angle)
+	c.MoveTo(-pointSize, -pointSize*0.6)
+	c.LineTo(0, 0)
+	c.LineTo(-pointSize, pointSize*0.6)
+	c.Restore()
+}
+
+// DrawPathArrow adds an arrow head to the end of the current path, following the path's direction.
+// This works for any path, such as a MultiCurve or an Arc.
+func (c *Context) DrawPathArrow(pointSize float64) error {
+	pm, err := c.MeasurePath()
+	if err != nil {
+		return err
+	}
+	x, y, angle := pm.PointAtT(1)
+	c.DrawArrowHead(x, y, angle, pointSize)
+	return nil
+}
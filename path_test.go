@@ -0,0 +1,182 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCopyPath(t *testing.T) {
+	_, context := createContext()
+	context.MoveTo(10, 20)
+	context.LineTo(100, 20)
+	context.CurveTo(120, 20, 140, 40, 140, 60)
+	context.ClosePath()
+
+	path, err := context.CopyPath()
+	if err != nil {
+		t.Fatalf("Unable to copy path. Error: %s\n", err)
+	}
+	expected := []PathDataType{PathMoveTo, PathLineTo, PathCurveTo, PathClosePath}
+	if path.Len() < len(expected) {
+		t.Fatalf("Expected at least %d segments, got %d\n", len(expected), path.Len())
+	}
+	for i, dataType := range expected {
+		if path.Segments[i].Type != dataType {
+			t.Errorf("Expected segment %d to be %v, got %v\n", i, dataType, path.Segments[i].Type)
+		}
+	}
+	start := path.Segments[0].Points[0]
+	if start.X != 10 || start.Y != 20 {
+		t.Errorf("Expected start point 10, 20, got %f, %f\n", start.X, start.Y)
+	}
+	if len(path.Segments[2].Points) != 3 {
+		t.Errorf("Expected curve to have 3 points, got %d\n", len(path.Segments[2].Points))
+	}
+
+	flat, err := context.CopyPathFlat()
+	if err != nil {
+		t.Fatalf("Unable to copy flat path. Error: %s\n", err)
+	}
+	for _, segment := range flat.Segments {
+		if segment.Type == PathCurveTo {
+			t.Errorf("Flat path should not contain curves\n")
+		}
+	}
+}
+
+func TestAppendPath(t *testing.T) {
+	_, context := createContext()
+	path := &Path{}
+	path.MoveTo(100, 100)
+	path.LineTo(200, 100)
+	path.LineTo(200, 200)
+	path.ClosePath()
+
+	context.AppendPath(path)
+	status := context.GetStatus()
+	if status != StatusSuccess {
+		t.Errorf("Expected status %q, got %q\n", StatusSuccess, status)
+	}
+
+	left, top, right, bottom := context.PathExtents()
+	if left != 100 || top != 100 || right != 200 || bottom != 200 {
+		t.Errorf("Expected extents 100, 100, 200, 200, got %f, %f, %f, %f\n", left, top, right, bottom)
+	}
+
+	copied, err := context.CopyPath()
+	if err != nil {
+		t.Fatalf("Unable to copy path. Error: %s\n", err)
+	}
+	if copied.Segments[1].Points[0].X != 200 {
+		t.Errorf("Expected appended line to 200, got %f\n", copied.Segments[1].Points[0].X)
+	}
+}
+
+func TestSubdividePath(t *testing.T) {
+	path := &Path{}
+	path.MoveTo(0, 0)
+	path.LineTo(100, 0)
+	path.CurveTo(100, 50, 50, 100, 0, 100)
+	path.ClosePath()
+
+	sub := path.Subdivide(10)
+	lines := 0
+	for i, segment := range sub.Segments {
+		if segment.Type == PathLineTo {
+			lines++
+			prev := sub.Segments[i-1].Points
+			dist := prev[len(prev)-1].Distance(segment.Points[0])
+			if dist > 10.0001 {
+				t.Errorf("Expected line length <= 10, got %f\n", dist)
+			}
+		}
+	}
+	// 10 lines for the first line, 9 for the closing line, which ClosePath finishes.
+	if lines != 19 {
+		t.Errorf("Expected 19 lines, got %d\n", lines)
+	}
+	last := sub.Segments[sub.Len()-1]
+	if last.Type != PathClosePath {
+		t.Errorf("Expected last segment to be %v, got %v\n", PathClosePath, last.Type)
+	}
+}
+
+func TestOffsetPath(t *testing.T) {
+	path := &Path{}
+	path.MoveTo(0, 0)
+	path.LineTo(100, 0)
+	path.LineTo(100, 100)
+	path.LineTo(0, 100)
+	path.ClosePath()
+
+	offset := path.Offset(10)
+	expected := [][2]float64{{10, 10}, {90, 10}, {90, 90}, {10, 90}}
+	for i, xy := range expected {
+		point := offset.Segments[i].Points[0]
+		if math.Abs(point.X-xy[0]) > 1e-9 || math.Abs(point.Y-xy[1]) > 1e-9 {
+			t.Errorf("Expected point %d to be %v, got %f, %f\n", i, xy, point.X, point.Y)
+		}
+	}
+	if offset.Segments[4].Type != PathClosePath {
+		t.Errorf("Expected segment 4 to be %v, got %v\n", PathClosePath, offset.Segments[4].Type)
+	}
+}
+
+func TestTransformPath(t *testing.T) {
+	_, context := createContext()
+	context.Rectangle(10, 10, 100, 100)
+	err := context.TransformPath(func(x, y float64) (float64, float64) {
+		return x * 2, y + 5
+	})
+	if err != nil {
+		t.Fatalf("Unable to transform path. Error: %s\n", err)
+	}
+	left, top, right, bottom := context.PathExtents()
+	if left != 20 || top != 15 || right != 220 || bottom != 115 {
+		t.Errorf("Expected extents 20, 15, 220, 115, got %f, %f, %f, %f\n", left, top, right, bottom)
+	}
+
+	path, _ := context.CopyPath()
+	jittered := path.Jitter(0.01, 0, 0)
+	for i, point := range jittered.Points() {
+		original := path.Points()[i]
+		if !point.Equals(original) {
+			t.Errorf("Expected zero offset jitter to leave points unchanged\n")
+		}
+	}
+}
+
+func TestPathMeasure(t *testing.T) {
+	path := &Path{}
+	path.MoveTo(0, 0)
+	path.LineTo(100, 0)
+	path.LineTo(100, 50)
+	path.ClosePath()
+
+	pm := NewPathMeasure(path)
+	expected := 100 + 50 + math.Hypot(100, 50)
+	if math.Abs(pm.Length()-expected) > 1e-9 {
+		t.Errorf("Expected length %f, got %f\n", expected, pm.Length())
+	}
+
+	x, y, angle := pm.PointAtDistance(125)
+	if x != 100 || y != 25 || angle != math.Pi/2 {
+		t.Errorf("Expected 100, 25, %f, got %f, %f, %f\n", math.Pi/2, x, y, angle)
+	}
+
+	x, y, angle = pm.PointAtT(0)
+	if x != 0 || y != 0 || angle != 0 {
+		t.Errorf("Expected 0, 0, 0, got %f, %f, %f\n", x, y, angle)
+	}
+
+	_, context := createContext()
+	context.Arc(200, 200, 100, 0, math.Pi, false)
+	pm, err := context.MeasurePath()
+	if err != nil {
+		t.Fatalf("Unable to measure path. Error: %s\n", err)
+	}
+	if math.Abs(pm.Length()-math.Pi*100) > 1 {
+		t.Errorf("Expected length close to %f, got %f\n", math.Pi*100, pm.Length())
+	}
+}
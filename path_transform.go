@@ -0,0 +1,328 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+import (
+	"math"
+
+	"github.com/bit101/bitlib/blmath"
+	"github.com/bit101/bitlib/geom"
+	"github.com/bit101/bitlib/noise"
+)
+
+// maxMiter limits how far a corner point can be pushed out when offsetting a path.
+const maxMiter = 4.0
+
+// Transform returns a new path with every point, including curve control points, passed through pointFunc.
+func (p *Path) Transform(pointFunc func(x, y float64) (float64, float64)) *Path {
+	path := &Path{Segments: make([]PathSegment, len(p.Segments))}
+	for i, segment := range p.Segments {
+		path.Segments[i].Type = segment.Type
+		for _, point := range segment.Points {
+			x, y := pointFunc(point.X, point.Y)
+			path.Segments[i].Points = append(path.Segments[i].Points, geom.NewPoint(x, y))
+		}
+	}
+	return path
+}
+
+// Subdivide returns a new path where no line or curve is longer than maxLength.
+// Lines are split into equal parts, curves are split into smaller curves.
+// The line implied by a ClosePath segment is also subdivided.
+func (p *Path) Subdivide(maxLength float64) *Path {
+	if maxLength <= 0 {
+		return p.Clone()
+	}
+	path := &Path{}
+	var start, current *geom.Point
+	for _, segment := range p.Segments {
+		switch segment.Type {
+		case PathMoveTo:
+			start, current = segment.Points[0], segment.Points[0]
+			path.MoveTo(current.X, current.Y)
+		case PathLineTo:
+			end := segment.Points[0]
+			if current == nil {
+				start, current = end, end
+				path.MoveTo(end.X, end.Y)
+				continue
+			}
+			subdivideLine(path, current, end, maxLength, true)
+			current = end
+		case PathCurveTo:
+			if current == nil {
+				current = segment.Points[0]
+				start = current
+				path.MoveTo(current.X, current.Y)
+			}
+			subdivideCurve(path, current, segment.Points[0], segment.Points[1], segment.Points[2], maxLength)
+			current = segment.Points[2]
+		case PathClosePath:
+			if current != nil {
+				// the final point is drawn by ClosePath itself.
+				subdivideLine(path, current, start, maxLength, false)
+			}
+			path.ClosePath()
+			current = start
+		}
+	}
+	return path
+}
+
+// subdivideLine adds lines from p0 to p1 to the path, none longer than maxLength.
+func subdivideLine(path *Path, p0, p1 *geom.Point, maxLength float64, includeEnd bool) {
+	count := math.Max(math.Ceil(p0.Distance(p1)/maxLength), 1)
+	for i := 1.0; i < count; i++ {
+		point := geom.LerpPoint(i/count, p0, p1)
+		path.LineTo(point.X, point.Y)
+	}
+	if includeEnd {
+		path.LineTo(p1.X, p1.Y)
+	}
+}
+
+// subdivideCurve adds curves from p0 to p3 to the path, none (approximately) longer than maxLength.
+func subdivideCurve(path *Path, p0, p1, p2, p3 *geom.Point, maxLength float64) {
+	// the average of the chord and the control polygon is a good estimate of curve length.
+	length := (p0.Distance(p3) + p0.Distance(p1) + p1.Distance(p2) + p2.Distance(p3)) / 2
+	count := int(math.Max(math.Ceil(length/maxLength), 1))
+	for i := 0; i < count; i++ {
+		left, right := splitCurve(1/float64(count-i), p0, p1, p2, p3)
+		path.CurveTo(left[1].X, left[1].Y, left[2].X, left[2].Y, left[3].X, left[3].Y)
+		p0, p1, p2, p3 = right[0], right[1], right[2], right[3]
+	}
+}
+
+// splitCurve splits a bezier curve at t using de Casteljau's algorithm.
+func splitCurve(t float64, p0, p1, p2, p3 *geom.Point) ([4]*geom.Point, [4]*geom.Point) {
+	p01 := geom.LerpPoint(t, p0, p1)
+	p12 := geom.LerpPoint(t, p1, p2)
+	p23 := geom.LerpPoint(t, p2, p3)
+	p012 := geom.LerpPoint(t, p01, p12)
+	p123 := geom.LerpPoint(t, p12, p23)
+	mid := geom.LerpPoint(t, p012, p123)
+	return [4]*geom.Point{p0, p01, p012, mid}, [4]*geom.Point{mid, p123, p23, p3}
+}
+
+// Jitter returns a new path with every point pushed out of place by Simplex noise.
+// freq scales the noise field. Lower values give smoother distortions.
+// offset controls how far each point is pushed.
+// z is the z param of Simplex3. Can be used to animate the noise.
+// Subdivide the path first to distort long, straight lines.
+func (p *Path) Jitter(freq, offset, z float64) *Path {
+	return p.Transform(func(x, y float64) (float64, float64) {
+		n := noise.Simplex3(x*freq, y*freq, z) * blmath.Tau
+		return x + math.Cos(n)*offset, y + math.Sin(n)*offset
+	})
+}
+
+// pathPiece is a single line or curve in a sub path, used for offsetting.
+type pathPiece struct {
+	points   []*geom.Point // start point, then the points of the segment.
+	implicit bool          // the closing line of a closed sub path.
+}
+
+// startTangent returns the direction the piece leaves its start point.
+func (pp pathPiece) startTangent() (float64, float64) {
+	start := pp.points[0]
+	for _, point := range pp.points[1:] {
+		if dx, dy := point.X-start.X, point.Y-start.Y; dx != 0 || dy != 0 {
+			return dx, dy
+		}
+	}
+	return 0, 0
+}
+
+// endTangent returns the direction the piece enters its end point.
+func (pp pathPiece) endTangent() (float64, float64) {
+	end := pp.points[len(pp.points)-1]
+	for i := len(pp.points) - 2; i >= 0; i-- {
+		if dx, dy := end.X-pp.points[i].X, end.Y-pp.points[i].Y; dx != 0 || dy != 0 {
+			return dx, dy
+		}
+	}
+	return 0, 0
+}
+
+// unitNormal returns the normalized right hand normal of a direction.
+func unitNormal(dx, dy float64) (float64, float64) {
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return 0, 0
+	}
+	return -dy / length, dx / length
+}
+
+// joinNormal returns the offset direction, scaled for miter, where two pieces meet.
+func joinNormal(inX, inY, outX, outY float64) (float64, float64) {
+	n0x, n0y := unitNormal(inX, inY)
+	n1x, n1y := unitNormal(outX, outY)
+	nx, ny := n0x+n1x, n0y+n1y
+	length := math.Hypot(nx, ny)
+	if length < 1e-9 {
+		if n0x == 0 && n0y == 0 {
+			return n1x, n1y
+		}
+		return n0x, n0y
+	}
+	nx, ny = nx/length, ny/length
+	if n0x == 0 && n0y == 0 || n1x == 0 && n1y == 0 {
+		return nx, ny
+	}
+	scale := math.Min(1/(nx*n0x+ny*n0y), maxMiter)
+	return nx * scale, ny * scale
+}
+
+// Offset returns a new path with every point moved along the path's normal by distance.
+// Positive distances move to the right of the direction of travel, negative to the left.
+// Curves are offset by moving their control points along the normals at each end,
+// which is an approximation. Subdivide the path first for more accuracy.
+func (p *Path) Offset(distance float64) *Path {
+	path := &Path{}
+	var pieces []pathPiece
+	var start, current *geom.Point
+	inSubPath := false
+
+	flush := func(closed bool) {
+		if !inSubPath {
+			return
+		}
+		if closed && !current.Equals(start) {
+			pieces = append(pieces, pathPiece{[]*geom.Point{current, start}, true})
+		}
+		offsetSubPath(path, start, pieces, closed, distance)
+		pieces = nil
+		inSubPath = false
+	}
+
+	for _, segment := range p.Segments {
+		switch segment.Type {
+		case PathMoveTo:
+			flush(false)
+			start, current = segment.Points[0], segment.Points[0]
+			inSubPath = true
+		case PathLineTo, PathCurveTo:
+			if !inSubPath {
+				inSubPath = true
+				if current == nil {
+					// with no current point, the first point acts as a MoveTo.
+					current = segment.Points[0]
+					if segment.Type == PathLineTo {
+						start = current
+						continue
+					}
+				}
+				start = current
+			}
+			points := append([]*geom.Point{current}, segment.Points...)
+			pieces = append(pieces, pathPiece{points: points})
+			current = segment.Points[len(segment.Points)-1]
+		case PathClosePath:
+			flush(true)
+			current = start
+		}
+	}
+	flush(false)
+	return path
+}
+
+// offsetSubPath adds an offset version of a single sub path to path.
+func offsetSubPath(path *Path, start *geom.Point, pieces []pathPiece, closed bool, distance float64) {
+	count := len(pieces)
+	if count == 0 {
+		path.MoveTo(start.X, start.Y)
+		if closed {
+			path.ClosePath()
+		}
+		return
+	}
+
+	// normal at the end of piece i, where it joins piece i+1.
+	vertexNormal := func(i int) (float64, float64) {
+		inX, inY := pieces[i].endTangent()
+		if i == count-1 && !closed {
+			return unitNormal(inX, inY)
+		}
+		outX, outY := pieces[(i+1)%count].startTangent()
+		return joinNormal(inX, inY, outX, outY)
+	}
+	offsetPoint := func(point *geom.Point, nx, ny float64) (float64, float64) {
+		return point.X + nx*distance, point.Y + ny*distance
+	}
+
+	var sx, sy float64
+	if closed {
+		sx, sy = vertexNormal(count - 1)
+	} else {
+		sx, sy = unitNormal(pieces[0].startTangent())
+	}
+	path.MoveTo(offsetPoint(start, sx, sy))
+
+	for i, piece := range pieces {
+		if piece.implicit {
+			continue
+		}
+		nx, ny := vertexNormal(i)
+		end := piece.points[len(piece.points)-1]
+		if len(piece.points) == 4 {
+			n0x, n0y := unitNormal(piece.startTangent())
+			n1x, n1y := unitNormal(piece.endTangent())
+			x1, y1 := offsetPoint(piece.points[1], n0x, n0y)
+			x2, y2 := offsetPoint(piece.points[2], n1x, n1y)
+			x3, y3 := offsetPoint(end, nx, ny)
+			path.CurveTo(x1, y1, x2, y2, x3, y3)
+		} else {
+			path.LineTo(offsetPoint(end, nx, ny))
+		}
+	}
+	if closed {
+		path.ClosePath()
+	}
+}
+
+// ReplacePath clears the current path and replaces it with the given path.
+func (c *Context) ReplacePath(path *Path) {
+	c.NewPath()
+	c.AppendPath(path)
+}
+
+// TransformPath passes every point in the current path through pointFunc, replacing the current path.
+func (c *Context) TransformPath(pointFunc func(x, y float64) (float64, float64)) error {
+	path, err := c.CopyPath()
+	if err != nil {
+		return err
+	}
+	c.ReplacePath(path.Transform(pointFunc))
+	return nil
+}
+
+// SubdividePath flattens the current path and splits it into lines no longer than maxLength, replacing the current path.
+func (c *Context) SubdividePath(maxLength float64) error {
+	path, err := c.CopyPathFlat()
+	if err != nil {
+		return err
+	}
+	c.ReplacePath(path.Subdivide(maxLength))
+	return nil
+}
+
+// JitterPath pushes every point in the current path out of place with Simplex noise, replacing the current path.
+// See Path.Jitter for a description of the params.
+func (c *Context) JitterPath(freq, offset, z float64) error {
+	path, err := c.CopyPath()
+	if err != nil {
+		return err
+	}
+	c.ReplacePath(path.Jitter(freq, offset, z))
+	return nil
+}
+
+// OffsetPath moves every point in the current path along the path's normal by distance, replacing the current path.
+// See Path.Offset for details.
+func (c *Context) OffsetPath(distance float64) error {
+	path, err := c.CopyPath()
+	if err != nil {
+		return err
+	}
+	c.ReplacePath(path.Offset(distance))
+	return nil
+}
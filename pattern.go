@@ -10,8 +10,11 @@ package cairo
 // #include <cairo/cairo-svg.h>
 // #include <stdlib.h>
 // #include <string.h>
+// extern void blcairoDeleteHandle(void *data);
 import "C"
 
+import "runtime"
+
 // PatternType represents a cairo_pattern_type_t
 type PatternType int
 
@@ -21,11 +24,36 @@ const (
 	PatternTypeSurface
 	PatternTypeLinear
 	PatternTypeRadial
+	PatternTypeMesh
+	PatternTypeRasterSource
 )
 
+// String returns the name of the pattern type.
+func (p PatternType) String() string {
+	switch p {
+	case PatternTypeSolid:
+		return "solid"
+	case PatternTypeSurface:
+		return "surface"
+	case PatternTypeLinear:
+		return "linear"
+	case PatternTypeRadial:
+		return "radial"
+	case PatternTypeMesh:
+		return "mesh"
+	case PatternTypeRasterSource:
+		return "raster source"
+	}
+	return "unknown"
+}
+
 // Pattern represents a cairo_pattern_t
 type Pattern struct {
 	pattern *C.cairo_pattern_t
+	// whether this value owns a reference, released by Destroy.
+	owned bool
+	// the value whose reference a borrowed pattern uses, kept reachable so it is not finalized.
+	owner any
 }
 
 ////////////////////////////
@@ -34,7 +62,7 @@ type Pattern struct {
 // CreateLinearGradient creates a pattern to be used as a linear gradient.
 func CreateLinearGradient(x0, y0, x1, y1 float64) *Pattern {
 	p := C.cairo_pattern_create_linear(C.double(x0), C.double(y0), C.double(x1), C.double(y1))
-	return &Pattern{p}
+	return newPattern(p)
 }
 
 // CreateRadialGradient creates a pattern to be used as a radial gradient.
@@ -43,25 +71,26 @@ func CreateRadialGradient(cx0, cy0, radius0, cx1, cy1, radius1 float64) *Pattern
 		C.double(cx0), C.double(cy0), C.double(radius0),
 		C.double(cx1), C.double(cy1), C.double(radius1),
 	)
-	return &Pattern{p}
+	return newPattern(p)
 }
 
 // CreateRGBPattern creates a solid RGB pattern.
 func CreateRGBPattern(red, green, blue float64) *Pattern {
 	p := C.cairo_pattern_create_rgb(C.double(red), C.double(green), C.double(blue))
-	return &Pattern{p}
+	return newPattern(p)
 }
 
 // CreateRGBAPattern creates a solid RGBA pattern.
 func CreateRGBAPattern(red, green, blue, alpha float64) *Pattern {
 	p := C.cairo_pattern_create_rgba(C.double(red), C.double(green), C.double(blue), C.double(alpha))
-	return &Pattern{p}
+	return newPattern(p)
 }
 
 // CreatePatternForSurface creates a pattern based on the surface passed in.
 func CreatePatternForSurface(surface *Surface) *Pattern {
+	defer runtime.KeepAlive(surface)
 	p := C.cairo_pattern_create_for_surface(surface.surface)
-	return &Pattern{p}
+	return newPattern(p)
 }
 
 ////////////////////////////
@@ -69,16 +98,19 @@ func CreatePatternForSurface(surface *Surface) *Pattern {
 
 // AddColorStopRGB adds an rgb color stop to this pattern.
 func (p *Pattern) AddColorStopRGB(offset, red, green, blue float64) {
+	defer runtime.KeepAlive(p)
 	C.cairo_pattern_add_color_stop_rgb(p.pattern, C.double(offset), C.double(red), C.double(green), C.double(blue))
 }
 
 // AddColorStopRGBA adds an rgba color stop to this pattern.
 func (p *Pattern) AddColorStopRGBA(offset, red, green, blue, alpha float64) {
+	defer runtime.KeepAlive(p)
 	C.cairo_pattern_add_color_stop_rgba(p.pattern, C.double(offset), C.double(red), C.double(green), C.double(blue), C.double(alpha))
 }
 
 // GetColorStopCount returns the number of color stops on this pattern.
 func (p *Pattern) GetColorStopCount() int {
+	defer runtime.KeepAlive(p)
 	var count C.int
 	C.cairo_pattern_get_color_stop_count(p.pattern, &count)
 	return int(count)
@@ -86,6 +118,7 @@ func (p *Pattern) GetColorStopCount() int {
 
 // GetColorStopRGBA returns the value for the color stop at the given index.
 func (p *Pattern) GetColorStopRGBA(index int) (offset, red, green, blue, alpha float64) {
+	defer runtime.KeepAlive(p)
 	var o C.double
 	var r C.double
 	var g C.double
@@ -97,6 +130,7 @@ func (p *Pattern) GetColorStopRGBA(index int) (offset, red, green, blue, alpha f
 
 // GetLinearPoints returns the values of the points defining a linear gradient.
 func (p *Pattern) GetLinearPoints() (x0, y0, x1, y1 float64) {
+	defer runtime.KeepAlive(p)
 	var cx0 C.double
 	var cy0 C.double
 	var cx1 C.double
@@ -107,6 +141,7 @@ func (p *Pattern) GetLinearPoints() (x0, y0, x1, y1 float64) {
 
 // GetRadialCircles returns the values of the circles defining a radial gradient.
 func (p *Pattern) GetRadialCircles() (x0, y0, r0, x1, y1, r1 float64) {
+	defer runtime.KeepAlive(p)
 	var cx0 C.double
 	var cy0 C.double
 	var cr0 C.double
@@ -122,6 +157,7 @@ func (p *Pattern) GetRadialCircles() (x0, y0, r0, x1, y1, r1 float64) {
 
 // GetRGBA returns the rgba values for a solid pattern.
 func (p *Pattern) GetRGBA() (red, green, blue, alpha float64) {
+	defer runtime.KeepAlive(p)
 	var r C.double
 	var g C.double
 	var b C.double
@@ -135,11 +171,13 @@ func (p *Pattern) GetRGBA() (red, green, blue, alpha float64) {
 
 // SetMatrix transforms the pattern according to the matrix passed.
 func (p *Pattern) SetMatrix(matrix *Matrix) {
+	defer runtime.KeepAlive(p)
 	C.cairo_pattern_set_matrix(p.pattern, matrix.Native())
 }
 
 // GetMatrix returns the current matrix in use on the pattern.
 func (p *Pattern) GetMatrix() *Matrix {
+	defer runtime.KeepAlive(p)
 	var matrix C.cairo_matrix_t
 	C.cairo_pattern_get_matrix(p.pattern, &matrix)
 	return &Matrix{
@@ -158,63 +196,74 @@ func (p *Pattern) GetMatrix() *Matrix {
 // CreateMesh creates a mesh pattern
 func CreateMesh() *Pattern {
 	p := C.cairo_pattern_create_mesh()
-	return &Pattern{p}
+	return newPattern(p)
 }
 
 // BeginPatch starts a patch definition.
 func (p *Pattern) BeginPatch() {
+	defer runtime.KeepAlive(p)
 	C.cairo_mesh_pattern_begin_patch(p.pattern)
 }
 
 // EndPatch completes a patch definition.
 func (p *Pattern) EndPatch() {
+	defer runtime.KeepAlive(p)
 	C.cairo_mesh_pattern_end_patch(p.pattern)
 }
 
 // MoveTo moves to an x, y point.
 func (p *Pattern) MoveTo(x, y float64) {
+	defer runtime.KeepAlive(p)
 	C.cairo_mesh_pattern_move_to(p.pattern, C.double(x), C.double(y))
 }
 
 // LineTo draws a line to an x, y point.
 func (p *Pattern) LineTo(x, y float64) {
+	defer runtime.KeepAlive(p)
 	C.cairo_mesh_pattern_line_to(p.pattern, C.double(x), C.double(y))
 }
 
 // CurveTo draws a bezier curve to a point through two control points.
 func (p *Pattern) CurveTo(x1, y1, x2, y2, x3, y3 float64) {
+	defer runtime.KeepAlive(p)
 	C.cairo_mesh_pattern_curve_to(p.pattern, C.double(x1), C.double(y1), C.double(x2), C.double(y2), C.double(x3), C.double(y3))
 }
 
 // SetControlPoint sets the x, y position of a given control point.
 func (p *Pattern) SetControlPoint(pointNum uint, x, y float64) {
+	defer runtime.KeepAlive(p)
 	C.cairo_mesh_pattern_set_control_point(p.pattern, C.uint(pointNum), C.double(x), C.double(y))
 }
 
 // SetCornerColorRGB sets the RGB color for a given corner.
 func (p *Pattern) SetCornerColorRGB(cornerNum uint, r, g, b float64) {
+	defer runtime.KeepAlive(p)
 	C.cairo_mesh_pattern_set_corner_color_rgb(p.pattern, C.uint(cornerNum), C.double(r), C.double(g), C.double(b))
 }
 
 // SetCornerColorRGBA sets the RGBA color for a given corner.
 func (p *Pattern) SetCornerColorRGBA(cornerNum uint, r, g, b, a float64) {
+	defer runtime.KeepAlive(p)
 	C.cairo_mesh_pattern_set_corner_color_rgba(p.pattern, C.uint(cornerNum), C.double(r), C.double(g), C.double(b), C.double(a))
 }
 
 // GetPatchCount returns the number of patches defined for this pattern.
 func (p *Pattern) GetPatchCount() uint {
+	defer runtime.KeepAlive(p)
 	var count C.uint
 	C.cairo_mesh_pattern_get_patch_count(p.pattern, &count)
 	return uint(count)
 }
 
-// TODO: need to implement cairo_path_t
-// func (p *Pattern) GetPath(patchNum uint) uint {
-// 	return C.cairo_mesh_pattern_get_path(p.pattern, C.uint(patchNum))
-// }
+// GetPath returns the path defining a given patch.
+func (p *Pattern) GetPath(patchNum uint) (*Path, error) {
+	defer runtime.KeepAlive(p)
+	return newPathFromNative(C.cairo_mesh_pattern_get_path(p.pattern, C.uint(patchNum)))
+}
 
 // GetControlPoint returns the control point for a given patch corner.
 func (p *Pattern) GetControlPoint(patchNum, pointNum uint) (float64, float64) {
+	defer runtime.KeepAlive(p)
 	var x C.double
 	var y C.double
 	C.cairo_mesh_pattern_get_control_point(p.pattern, C.uint(patchNum), C.uint(pointNum), &x, &y)
@@ -223,6 +272,7 @@ func (p *Pattern) GetControlPoint(patchNum, pointNum uint) (float64, float64) {
 
 // GetCornerColorRGBA returns the RGBA color for a given patch corner.
 func (p *Pattern) GetCornerColorRGBA(patchNum, pointNum uint) (float64, float64, float64, float64) {
+	defer runtime.KeepAlive(p)
 	var r C.double
 	var g C.double
 	var b C.double
@@ -233,24 +283,108 @@ func (p *Pattern) GetCornerColorRGBA(patchNum, pointNum uint) (float64, float64,
 
 // SetFilter sets the filter to be used on this pattern.
 func (p *Pattern) SetFilter(filter Filter) {
+	defer runtime.KeepAlive(p)
 	C.cairo_pattern_set_filter(p.pattern, C.cairo_filter_t(filter))
 }
 
 // GetFilter returns the filter currently in use on this pattern.
 func (p *Pattern) GetFilter() Filter {
+	defer runtime.KeepAlive(p)
 	return Filter(C.cairo_pattern_get_filter(p.pattern))
 }
 
-// cairo_filter_t	cairo_pattern_get_filter ()
+// SetExtend sets how the pattern is drawn outside of its natural area,
+// such as outside the bounds of a surface pattern or beyond the ends of a gradient.
+func (p *Pattern) SetExtend(extend Extend) {
+	defer runtime.KeepAlive(p)
+	C.cairo_pattern_set_extend(p.pattern, C.cairo_extend_t(extend))
+}
+
+// GetExtend returns the extend mode currently in use on this pattern.
+func (p *Pattern) GetExtend() Extend {
+	defer runtime.KeepAlive(p)
+	return Extend(C.cairo_pattern_get_extend(p.pattern))
+}
+
+////////////////////////////
+// surface methods
+
+// GetSurface returns the surface of a surface pattern.
+// The returned surface holds its own reference and should be destroyed when done.
+func (p *Pattern) GetSurface() (*Surface, error) {
+	defer runtime.KeepAlive(p)
+	var surface *C.cairo_surface_t
+	status := Status(C.cairo_pattern_get_surface(p.pattern, &surface))
+	if status != StatusSuccess {
+		return nil, status
+	}
+	return newSurface(C.cairo_surface_reference(surface), 0, 0), nil
+}
+
+////////////////////////////
+// lifetime and status methods
+
+// GetType returns the type of this pattern.
+func (p *Pattern) GetType() PatternType {
+	defer runtime.KeepAlive(p)
+	return PatternType(C.cairo_pattern_get_type(p.pattern))
+}
 
-// TODO:
-// cairo_status_t	cairo_pattern_get_surface ()
-// cairo_pattern_t *	cairo_pattern_reference ()
-// void	cairo_pattern_destroy ()
-// cairo_status_t	cairo_pattern_status ()
-// void	cairo_pattern_set_extend ()
-// cairo_extend_t	cairo_pattern_get_extend ()
-// cairo_pattern_type_t	cairo_pattern_get_type ()
-// unsigned int	cairo_pattern_get_reference_count ()
-// cairo_status_t	cairo_pattern_set_user_data ()
-// void *	cairo_pattern_get_user_data ()
+// Status returns an error if the pattern is in an error state.
+func (p *Pattern) Status() error {
+	defer runtime.KeepAlive(p)
+	status := Status(C.cairo_pattern_status(p.pattern))
+	if status != StatusSuccess {
+		return status
+	}
+	return nil
+}
+
+// Reference returns a new Pattern owning another reference to this pattern.
+func (p *Pattern) Reference() *Pattern {
+	defer runtime.KeepAlive(p)
+	return newPattern(C.cairo_pattern_reference(p.pattern))
+}
+
+// Destroy releases the reference owned by this pattern, freeing it when no other references exist.
+// It is safe to call more than once.
+func (p *Pattern) Destroy() {
+	if !p.owned {
+		return
+	}
+	p.owned = false
+	runtime.SetFinalizer(p, nil)
+	C.cairo_pattern_destroy(p.pattern)
+	livePatterns.Add(-1)
+	untrackObject(p)
+}
+
+// GetReferenceCount gets the number of objects that are holding a reference to this pattern.
+func (p *Pattern) GetReferenceCount() int {
+	defer runtime.KeepAlive(p)
+	return int(C.cairo_pattern_get_reference_count(p.pattern))
+}
+
+// SetUserData attaches a value to the pattern under the given key, replacing any previous value.
+// A nil value removes the key.
+func (p *Pattern) SetUserData(key string, value any) error {
+	defer runtime.KeepAlive(p)
+	k := userDataKey(key)
+	if value == nil {
+		C.cairo_pattern_set_user_data(p.pattern, k, nil, nil)
+		return nil
+	}
+	data := newHandleData(value)
+	status := Status(C.cairo_pattern_set_user_data(p.pattern, k, data, C.cairo_destroy_func_t(C.blcairoDeleteHandle)))
+	if status != StatusSuccess {
+		blcairoDeleteHandle(data)
+		return status
+	}
+	return nil
+}
+
+// GetUserData returns the value attached to the pattern under the given key, or nil.
+func (p *Pattern) GetUserData(key string) any {
+	defer runtime.KeepAlive(p)
+	return handleDataValue(C.cairo_pattern_get_user_data(p.pattern, userDataKey(key)))
+}
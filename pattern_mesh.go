@@ -0,0 +1,138 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+import (
+	"math"
+
+	"github.com/bit101/bitlib/blcolor"
+	"github.com/bit101/bitlib/geom"
+)
+
+// SetCornerColor sets the color of one corner of the current mesh patch.
+func (p *Pattern) SetCornerColor(cornerNum uint, color blcolor.Color) {
+	p.SetCornerColorRGBA(cornerNum, color.R, color.G, color.B, color.A)
+}
+
+// CreateConicSweep creates a mesh pattern filling the ring between two radii, from startAngle to endAngle,
+// with the colors spread evenly along the sweep. An inner radius of 0 gives a pie slice.
+// Outside of the ring the pattern is transparent.
+func CreateConicSweep(cx, cy, innerRadius, outerRadius, startAngle, endAngle float64, colors []blcolor.Color, space ColorSpace) *Pattern {
+	p := CreateMesh()
+	if len(colors) == 0 {
+		return p
+	}
+	p.addConicPatches(cx, cy, innerRadius, outerRadius, startAngle, endAngle, func(t float64) blcolor.Color {
+		return colorAt(colors, t, space)
+	})
+	return p
+}
+
+// CreateRectangleMesh creates a mesh pattern for a rectangle with a color at each corner,
+// blending smoothly between them.
+func CreateRectangleMesh(x, y, w, h float64, topLeft, topRight, bottomRight, bottomLeft blcolor.Color) *Pattern {
+	p := CreateMesh()
+	p.BeginPatch()
+	p.MoveTo(x, y)
+	p.LineTo(x+w, y)
+	p.LineTo(x+w, y+h)
+	p.LineTo(x, y+h)
+	p.SetCornerColor(0, topLeft)
+	p.SetCornerColor(1, topRight)
+	p.SetCornerColor(2, bottomRight)
+	p.SetCornerColor(3, bottomLeft)
+	p.EndPatch()
+	return p
+}
+
+// CreatePolylineGradient creates a mesh pattern forming a band of the given width along a polyline,
+// with the colors spread evenly along its length. Fill or paint with it to draw a gradient stroke.
+func CreatePolylineGradient(points geom.PointList, width float64, colors []blcolor.Color, space ColorSpace) *Pattern {
+	p := CreateMesh()
+	if len(points) < 2 || len(colors) == 0 {
+		return p
+	}
+	length := points.Length()
+	if length == 0 {
+		return p
+	}
+
+	// segment normals, pointing right of the direction of travel.
+	normals := make([]*geom.Point, len(points)-1)
+	for i := range normals {
+		dx := points[i+1].X - points[i].X
+		dy := points[i+1].Y - points[i].Y
+		dist := math.Hypot(dx, dy)
+		if dist == 0 {
+			normals[i] = geom.NewPoint(0, 0)
+			continue
+		}
+		normals[i] = geom.NewPoint(-dy/dist, dx/dist)
+	}
+
+	// offsets of each point to the right edge of the band, mitered at the corners.
+	half := width / 2
+	offsets := make([]*geom.Point, len(points))
+	for i := range points {
+		var n *geom.Point
+		switch i {
+		case 0:
+			n = normals[0]
+		case len(points) - 1:
+			n = normals[i-1]
+		default:
+			n0, n1 := normals[i-1], normals[i]
+			nx, ny := n0.X+n1.X, n0.Y+n1.Y
+			dist := math.Hypot(nx, ny)
+			if dist == 0 {
+				n = n1
+				break
+			}
+			nx, ny = nx/dist, ny/dist
+			scale := maxMiter
+			if dot := nx*n0.X + ny*n0.Y; dot > 0 {
+				scale = math.Min(1/dot, maxMiter)
+			}
+			n = geom.NewPoint(nx*scale, ny*scale)
+		}
+		offsets[i] = geom.NewPoint(n.X*half, n.Y*half)
+	}
+
+	dist := 0.0
+	for i := 0; i < len(points)-1; i++ {
+		p0, p1 := points[i], points[i+1]
+		o0, o1 := offsets[i], offsets[i+1]
+		c0 := colorAt(colors, dist/length, space)
+		dist += math.Hypot(p1.X-p0.X, p1.Y-p0.Y)
+		c1 := colorAt(colors, dist/length, space)
+
+		p.BeginPatch()
+		p.MoveTo(p0.X-o0.X, p0.Y-o0.Y)
+		p.LineTo(p1.X-o1.X, p1.Y-o1.Y)
+		p.LineTo(p1.X+o1.X, p1.Y+o1.Y)
+		p.LineTo(p0.X+o0.X, p0.Y+o0.Y)
+		p.SetCornerColor(0, c0)
+		p.SetCornerColor(1, c1)
+		p.SetCornerColor(2, c1)
+		p.SetCornerColor(3, c0)
+		p.EndPatch()
+	}
+	return p
+}
+
+// CreateTriangleMesh creates a mesh pattern with one patch per triangle, colored at each vertex by colorFunc.
+// Triangles that share a vertex get the same color there, so a triangulation is smoothly (Gouraud) shaded.
+// Paint with it, or set it as the source before FillTriangleList.
+func CreateTriangleMesh(triangles geom.TriangleList, colorFunc func(x, y float64) blcolor.Color) *Pattern {
+	p := CreateMesh()
+	for _, t := range triangles {
+		p.BeginPatch()
+		p.MoveTo(t.PointA.X, t.PointA.Y)
+		p.LineTo(t.PointB.X, t.PointB.Y)
+		p.LineTo(t.PointC.X, t.PointC.Y)
+		p.SetCornerColor(0, colorFunc(t.PointA.X, t.PointA.Y))
+		p.SetCornerColor(1, colorFunc(t.PointB.X, t.PointB.Y))
+		p.SetCornerColor(2, colorFunc(t.PointC.X, t.PointC.Y))
+		p.EndPatch()
+	}
+	return p
+}
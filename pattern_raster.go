@@ -0,0 +1,124 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+// #include <cairo/cairo.h>
+// extern cairo_surface_t *blcairoRasterAcquire(cairo_pattern_t *pattern, void *callback_data, cairo_surface_t *target, cairo_rectangle_int_t *extents);
+// extern void blcairoRasterRelease(cairo_pattern_t *pattern, void *callback_data, cairo_surface_t *surface);
+// extern cairo_status_t blcairoRasterCopy(cairo_pattern_t *pattern, void *callback_data, cairo_pattern_t *other);
+// extern void blcairoRasterFinish(cairo_pattern_t *pattern, void *callback_data);
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/bit101/bitlib/blcolor"
+	"github.com/bit101/bitlib/blmath"
+)
+
+// rasterSource holds the Go callback for a raster source pattern.
+// cairo copies raster source patterns into recording surfaces and vector output, sharing the callback data.
+// refs counts the original pattern and its copies, and the handle is released when the last one is finished.
+type rasterSource struct {
+	colorFunc     func(x, y float64) blcolor.Color
+	width, height int
+	refs          atomic.Int32
+}
+
+//export blcairoRasterAcquire
+func blcairoRasterAcquire(pattern *C.cairo_pattern_t, callbackData unsafe.Pointer, target *C.cairo_surface_t, extents *C.cairo_rectangle_int_t) (surface *C.cairo_surface_t) {
+	// a panic must not unwind through cairo.
+	defer func() {
+		if recover() != nil {
+			if surface != nil {
+				C.cairo_surface_destroy(surface)
+			}
+			surface = nil
+		}
+	}()
+
+	rs := handleDataValue(callbackData).(*rasterSource)
+	x0, y0, w, h := 0, 0, rs.width, rs.height
+	if extents != nil {
+		x0, y0, w, h = int(extents.x), int(extents.y), int(extents.width), int(extents.height)
+	}
+	surface = C.cairo_image_surface_create(C.cairo_format_t(FormatARGB32), C.int(w), C.int(h))
+	if Status(C.cairo_surface_status(surface)) != StatusSuccess {
+		return surface
+	}
+
+	C.cairo_surface_flush(surface)
+	stride := int(C.cairo_image_surface_get_stride(surface))
+	data := unsafe.Slice((*byte)(unsafe.Pointer(C.cairo_image_surface_get_data(surface))), stride*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			// sample the center of each pixel.
+			color := rs.colorFunc(float64(x0+x)+0.5, float64(y0+y)+0.5)
+			a := blmath.Clamp(color.A, 0, 1)
+			index := y*stride + x*4
+			data[index] = byte(blmath.Clamp(color.B, 0, 1)*a*255 + 0.5)
+			data[index+1] = byte(blmath.Clamp(color.G, 0, 1)*a*255 + 0.5)
+			data[index+2] = byte(blmath.Clamp(color.R, 0, 1)*a*255 + 0.5)
+			data[index+3] = byte(a*255 + 0.5)
+		}
+	}
+	C.cairo_surface_mark_dirty(surface)
+	// place the image at the sampled area.
+	C.cairo_surface_set_device_offset(surface, C.double(-x0), C.double(-y0))
+	return surface
+}
+
+//export blcairoRasterRelease
+func blcairoRasterRelease(pattern *C.cairo_pattern_t, callbackData unsafe.Pointer, surface *C.cairo_surface_t) {
+	C.cairo_surface_destroy(surface)
+}
+
+//export blcairoRasterCopy
+func blcairoRasterCopy(pattern *C.cairo_pattern_t, callbackData unsafe.Pointer, other *C.cairo_pattern_t) C.cairo_status_t {
+	handleDataValue(callbackData).(*rasterSource).refs.Add(1)
+	return C.cairo_status_t(StatusSuccess)
+}
+
+//export blcairoRasterFinish
+func blcairoRasterFinish(pattern *C.cairo_pattern_t, callbackData unsafe.Pointer) {
+	if handleDataValue(callbackData).(*rasterSource).refs.Add(-1) == 0 {
+		blcairoDeleteHandle(callbackData)
+	}
+}
+
+// CreateRasterSourcePattern creates a pattern whose pixels are produced by a Go function of x, y.
+// Pixels are only computed for the area being drawn, so filling a small path only samples the path's extents.
+// The pattern covers 0, 0 to w, h in pattern space and is transparent outside of that, unless extended with SetExtend.
+// colorFunc is called for the center of each pixel and may be called again whenever the pattern is drawn.
+// Recording and vector surfaces keep their own copy of the pattern and call colorFunc when they are replayed or finished,
+// even if the pattern has been destroyed by then.
+func CreateRasterSourcePattern(w, h int, colorFunc func(x, y float64) blcolor.Color) (*Pattern, error) {
+	if w <= 0 || h <= 0 {
+		return nil, fmt.Errorf("invalid raster source size %d x %d", w, h)
+	}
+	if colorFunc == nil {
+		return nil, errors.New("raster source color function is nil")
+	}
+	rs := &rasterSource{colorFunc: colorFunc, width: w, height: h}
+	rs.refs.Store(1)
+	data := newHandleData(rs)
+	pattern := newPattern(C.cairo_pattern_create_raster_source(data, C.cairo_content_t(ContentColorAlpha), C.int(w), C.int(h)))
+	if err := pattern.Status(); err != nil {
+		blcairoDeleteHandle(data)
+		pattern.Destroy()
+		return nil, err
+	}
+
+	// the pattern and each copy cairo makes of it hold a reference to the callback handle,
+	// so recordings and vector surfaces can still draw it after the pattern is destroyed.
+	C.cairo_raster_source_pattern_set_copy(pattern.pattern,
+		C.cairo_raster_source_copy_func_t(C.blcairoRasterCopy))
+	C.cairo_raster_source_pattern_set_finish(pattern.pattern,
+		C.cairo_raster_source_finish_func_t(C.blcairoRasterFinish))
+	C.cairo_raster_source_pattern_set_acquire(pattern.pattern,
+		C.cairo_raster_source_acquire_func_t(C.blcairoRasterAcquire),
+		C.cairo_raster_source_release_func_t(C.blcairoRasterRelease))
+	return pattern, nil
+}
@@ -0,0 +1,293 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bit101/bitlib/blcolor"
+	"github.com/bit101/bitlib/geom"
+)
+
+func TestPatternExtend(t *testing.T) {
+	pattern := CreateLinearGradient(0, 0, 100, 0)
+	defer pattern.Destroy()
+	if pattern.GetType() != PatternTypeLinear {
+		t.Errorf("Expected pattern type %v, got %v\n", PatternTypeLinear, pattern.GetType())
+	}
+	if pattern.GetExtend() != ExtendPad {
+		t.Errorf("Expected gradient default extend %v, got %v\n", ExtendPad, pattern.GetExtend())
+	}
+	pattern.SetExtend(ExtendReflect)
+	if pattern.GetExtend() != ExtendReflect {
+		t.Errorf("Expected extend %v, got %v\n", ExtendReflect, pattern.GetExtend())
+	}
+	if err := pattern.Status(); err != nil {
+		t.Errorf("Expected no pattern error, got %s\n", err)
+	}
+}
+
+func TestPatternSurface(t *testing.T) {
+	surface := NewSurface(20, 10)
+	pattern := CreatePatternForSurface(surface)
+	if pattern.GetType() != PatternTypeSurface {
+		t.Errorf("Expected pattern type %v, got %v\n", PatternTypeSurface, pattern.GetType())
+	}
+	patternSurface, err := pattern.GetSurface()
+	if err != nil {
+		t.Errorf("Expected no error getting surface, got %s\n", err)
+		return
+	}
+	if patternSurface.GetWidth() != 20 || patternSurface.GetHeight() != 10 {
+		t.Errorf("Expected surface size 20 x 10, got %d x %d\n", patternSurface.GetWidth(), patternSurface.GetHeight())
+	}
+	patternSurface.Destroy()
+
+	solid := CreateRGBPattern(1, 0, 0)
+	if _, err := solid.GetSurface(); err == nil {
+		t.Errorf("Expected an error getting the surface of a solid pattern\n")
+	}
+	solid.Destroy()
+
+	reference := pattern.Reference()
+	if pattern.GetReferenceCount() != 2 {
+		t.Errorf("Expected reference count 2, got %d\n", pattern.GetReferenceCount())
+	}
+	pattern.Destroy()
+	pattern.Destroy()
+	if reference.GetReferenceCount() != 1 {
+		t.Errorf("Expected reference count 1 after destroying twice, got %d\n", reference.GetReferenceCount())
+	}
+	reference.Destroy()
+}
+
+func TestPatternUserData(t *testing.T) {
+	pattern := CreateRGBPattern(0, 0, 0)
+	defer pattern.Destroy()
+	if err := pattern.SetUserData("name", "black"); err != nil {
+		t.Errorf("Expected no error setting user data, got %s\n", err)
+	}
+	if pattern.GetUserData("name") != "black" {
+		t.Errorf("Expected user data %q, got %v\n", "black", pattern.GetUserData("name"))
+	}
+	if pattern.GetUserData("other") != nil {
+		t.Errorf("Expected nil user data for unset key\n")
+	}
+	pattern.SetUserData("name", nil)
+	if pattern.GetUserData("name") != nil {
+		t.Errorf("Expected nil user data after removing key\n")
+	}
+}
+
+func TestTilePattern(t *testing.T) {
+	surface := NewSurface(100, 100)
+	context := NewContext(surface)
+	pattern, err := CreateDotPattern(10, 2, blcolor.RGB(0, 0, 0))
+	if err != nil {
+		t.Errorf("Expected no error creating tile pattern, got %s\n", err)
+		return
+	}
+	defer pattern.Destroy()
+	if pattern.GetExtend() != ExtendRepeat {
+		t.Errorf("Expected extend %v, got %v\n", ExtendRepeat, pattern.GetExtend())
+	}
+	context.SetSource(pattern)
+	context.Paint()
+	surface.Flush()
+
+	data := getData(surface, t)
+	if _, _, _, a := surface.GetPixel(data, 45, 75); a != 255 {
+		t.Errorf("Expected a dot at 45, 75, got alpha %d\n", a)
+	}
+	if _, _, _, a := surface.GetPixel(data, 40, 70); a != 0 {
+		t.Errorf("Expected no dot at 40, 70, got alpha %d\n", a)
+	}
+
+	pattern.SetTransform(5, 5, 0, 1)
+	context.SetOperator(OperatorSource)
+	context.Paint()
+	surface.Flush()
+	data = getData(surface, t)
+	if _, _, _, a := surface.GetPixel(data, 40, 70); a != 255 {
+		t.Errorf("Expected a dot at 40, 70 after transform, got alpha %d\n", a)
+	}
+}
+
+func TestRasterSourcePattern(t *testing.T) {
+	surface := NewSurface(100, 100)
+	context := NewContext(surface)
+	pattern, err := CreateRasterSourcePattern(100, 100, func(x, y float64) blcolor.Color {
+		if x < 50 {
+			return blcolor.RGB(1, 0, 0)
+		}
+		return blcolor.RGB(0, 0, 1)
+	})
+	if err != nil {
+		t.Errorf("Expected no error creating raster source pattern, got %s\n", err)
+		return
+	}
+	defer pattern.Destroy()
+	if pattern.GetType() != PatternTypeRasterSource {
+		t.Errorf("Expected pattern type %v, got %v\n", PatternTypeRasterSource, pattern.GetType())
+	}
+	context.SetSource(pattern)
+	context.FillRectangle(20, 20, 60, 60)
+	surface.Flush()
+
+	data := getData(surface, t)
+	if r, g, b, a := surface.GetPixel(data, 30, 50); r != 255 || g != 0 || b != 0 || a != 255 {
+		t.Errorf("Expected red at 30, 50, got %d, %d, %d, %d\n", r, g, b, a)
+	}
+	if r, g, b, a := surface.GetPixel(data, 70, 50); r != 0 || g != 0 || b != 255 || a != 255 {
+		t.Errorf("Expected blue at 70, 50, got %d, %d, %d, %d\n", r, g, b, a)
+	}
+	if _, _, _, a := surface.GetPixel(data, 10, 10); a != 0 {
+		t.Errorf("Expected nothing drawn outside the rectangle, got alpha %d\n", a)
+	}
+}
+
+func TestRasterSourcePatternReplay(t *testing.T) {
+	recording, err := NewUnboundedRecordingSurface(ContentColorAlpha)
+	if err != nil {
+		t.Fatalf("Unable to create recording surface. Error: %s\n", err)
+	}
+	defer recording.Destroy()
+	pattern, err := CreateRasterSourcePattern(100, 100, func(x, y float64) blcolor.Color {
+		return blcolor.RGB(0, 1, 0)
+	})
+	if err != nil {
+		t.Fatalf("Unable to create raster source pattern. Error: %s\n", err)
+	}
+	recordingContext := NewContext(recording)
+	recordingContext.SetSource(pattern)
+	recordingContext.FillRectangle(20, 20, 60, 60)
+	recordingContext.Destroy()
+	// the recording keeps its own copy of the pattern.
+	pattern.Destroy()
+
+	surface := NewSurface(100, 100)
+	defer surface.Destroy()
+	context := NewContext(surface)
+	defer context.Destroy()
+	context.Replay(recording, 0, 0, 1)
+	if err := context.Err(); err != nil {
+		t.Fatalf("Unable to replay recording. Error: %s\n", err)
+	}
+	surface.Flush()
+
+	data := getData(surface, t)
+	if r, g, b, a := surface.GetPixel(data, 50, 50); r != 0 || g != 255 || b != 0 || a != 255 {
+		t.Errorf("Expected green at 50, 50 after replay, got %d, %d, %d, %d\n", r, g, b, a)
+	}
+	if _, _, _, a := surface.GetPixel(data, 10, 10); a != 0 {
+		t.Errorf("Expected nothing drawn outside the rectangle, got alpha %d\n", a)
+	}
+}
+
+func TestGradientColors(t *testing.T) {
+	colors := []blcolor.Color{blcolor.RGB(1, 0, 0), blcolor.RGB(0, 0, 1)}
+	pattern := CreateLinearGradientColors(0, 0, 100, 0, colors, ColorSpaceSRGB)
+	if pattern.GetColorStopCount() != 2 {
+		t.Errorf("Expected 2 color stops, got %d\n", pattern.GetColorStopCount())
+	}
+	pattern.Destroy()
+
+	pattern = CreateLinearGradientColors(0, 0, 100, 0, colors, ColorSpaceOKLab)
+	defer pattern.Destroy()
+	if pattern.GetColorStopCount() != gradientSteps+1 {
+		t.Errorf("Expected %d color stops, got %d\n", gradientSteps+1, pattern.GetColorStopCount())
+	}
+	offset, r, g, b, a := pattern.GetColorStopRGBA(gradientSteps)
+	if offset != 1 || math.Abs(r) > 0.001 || math.Abs(g) > 0.001 || math.Abs(b-1) > 0.001 || a != 1 {
+		t.Errorf("Expected last stop blue at 1, got %f: %f, %f, %f, %f\n", offset, r, g, b, a)
+	}
+
+	mid := InterpolateColor(colors[0], colors[1], 0.5, ColorSpaceHSV)
+	if math.Abs(mid.R-1) > 0.001 || math.Abs(mid.G) > 0.001 || math.Abs(mid.B-1) > 0.001 {
+		t.Errorf("Expected magenta between red and blue in hsv, got %v\n", mid)
+	}
+}
+
+func TestConicGradient(t *testing.T) {
+	surface := NewSurface(100, 100)
+	context := NewContext(surface)
+	colors := []blcolor.Color{blcolor.RGB(1, 0, 0), blcolor.RGB(0, 0, 1)}
+	pattern := CreateConicGradientColors(50, 50, 50, 0, colors, ColorSpaceSRGB)
+	defer pattern.Destroy()
+	if pattern.GetType() != PatternTypeMesh {
+		t.Errorf("Expected pattern type %v, got %v\n", PatternTypeMesh, pattern.GetType())
+	}
+	context.SetSource(pattern)
+	context.Paint()
+	surface.Flush()
+
+	data := getData(surface, t)
+	// just clockwise of the start angle is nearly red, just before the end is nearly blue.
+	if r, _, b, _ := surface.GetPixel(data, 90, 52); r < 240 || b > 15 {
+		t.Errorf("Expected red near the start angle, got r %d, b %d\n", r, b)
+	}
+	if r, _, b, _ := surface.GetPixel(data, 90, 48); r > 15 || b < 240 {
+		t.Errorf("Expected blue near the end angle, got r %d, b %d\n", r, b)
+	}
+}
+
+func TestRectangleMesh(t *testing.T) {
+	surface := NewSurface(100, 100)
+	context := NewContext(surface)
+	pattern := CreateRectangleMesh(0, 0, 100, 100,
+		blcolor.RGB(1, 0, 0), blcolor.RGB(0, 1, 0), blcolor.RGB(0, 0, 1), blcolor.RGB(1, 1, 1))
+	defer pattern.Destroy()
+	if pattern.GetPatchCount() != 1 {
+		t.Errorf("Expected 1 patch, got %d\n", pattern.GetPatchCount())
+	}
+	context.SetSource(pattern)
+	context.Paint()
+	surface.Flush()
+
+	data := getData(surface, t)
+	if r, g, b, _ := surface.GetPixel(data, 0, 0); r < 245 || g > 10 || b > 10 {
+		t.Errorf("Expected red at top left, got %d, %d, %d\n", r, g, b)
+	}
+	if r, g, b, _ := surface.GetPixel(data, 99, 99); r > 10 || g > 10 || b < 245 {
+		t.Errorf("Expected blue at bottom right, got %d, %d, %d\n", r, g, b)
+	}
+}
+
+func TestTriangleAndPolylineMesh(t *testing.T) {
+	triangles := geom.TriangleList{
+		geom.NewTriangle(0, 0, 100, 0, 0, 100),
+		geom.NewTriangle(100, 0, 100, 100, 0, 100),
+	}
+	pattern := CreateTriangleMesh(triangles, func(x, y float64) blcolor.Color {
+		return blcolor.Grey(x / 100)
+	})
+	if pattern.GetPatchCount() != 2 {
+		t.Errorf("Expected 2 patches, got %d\n", pattern.GetPatchCount())
+	}
+	r, g, b, a := pattern.GetCornerColorRGBA(1, 1)
+	if r != 1 || g != 1 || b != 1 || a != 1 {
+		t.Errorf("Expected white corner at 100, 100, got %f, %f, %f, %f\n", r, g, b, a)
+	}
+	pattern.Destroy()
+
+	points := geom.PointList{geom.NewPoint(0, 50), geom.NewPoint(50, 50), geom.NewPoint(50, 100)}
+	pattern = CreatePolylineGradient(points, 10, []blcolor.Color{blcolor.RGB(0, 0, 0), blcolor.RGB(1, 1, 1)}, ColorSpaceSRGB)
+	defer pattern.Destroy()
+	if pattern.GetPatchCount() != 2 {
+		t.Errorf("Expected 2 patches, got %d\n", pattern.GetPatchCount())
+	}
+	// the outside corner of the bend is mitered out to 55, 45.
+	path, err := pattern.GetPath(0)
+	if err != nil {
+		t.Errorf("Expected no error getting patch path, got %s\n", err)
+		return
+	}
+	corner := path.Segments[1].Points[2]
+	if math.Abs(corner.X-55) > 0.001 || math.Abs(corner.Y-45) > 0.001 {
+		t.Errorf("Expected mitered corner at 55, 45, got %f, %f\n", corner.X, corner.Y)
+	}
+	r, _, _, _ = pattern.GetCornerColorRGBA(0, 1)
+	if math.Abs(r-0.5) > 0.001 {
+		t.Errorf("Expected mid grey at the bend, got %f\n", r)
+	}
+}
@@ -0,0 +1,90 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+import (
+	"fmt"
+
+	"github.com/bit101/bitlib/blcolor"
+)
+
+// CreateTilePattern creates a repeating pattern from a w x h tile drawn by the render function.
+// The tile is recorded as vector drawing, so it stays sharp when scaled and in pdf and svg output.
+// Set the pattern as a source with SetSource to fill any shape with it, and position it with SetTransform.
+func CreateTilePattern(w, h float64, render func(context *Context, w, h float64)) (*Pattern, error) {
+	if w <= 0 || h <= 0 {
+		return nil, fmt.Errorf("invalid tile size %f x %f", w, h)
+	}
+	surface, err := NewRecordingSurface(ContentColorAlpha, 0, 0, w, h)
+	if err != nil {
+		return nil, err
+	}
+	defer surface.Destroy()
+
+	context := NewContext(surface)
+	render(context, w, h)
+	status := context.GetStatus()
+	context.Destroy()
+	if status != StatusSuccess {
+		return nil, status
+	}
+
+	pattern := CreatePatternForSurface(surface)
+	pattern.SetExtend(ExtendRepeat)
+	return pattern, pattern.Status()
+}
+
+// SetTransform places the pattern in user space. The pattern is scaled, then rotated, then moved to x, y.
+// This replaces any matrix previously set on the pattern.
+func (p *Pattern) SetTransform(x, y, rotation, scale float64) {
+	matrix := NewMatrix()
+	matrix.InitTranslate(x, y)
+	matrix.Rotate(rotation)
+	matrix.Scale(scale, scale)
+	// a pattern matrix maps user space to pattern space, the inverse of placing the pattern.
+	matrix.Invert()
+	p.SetMatrix(matrix)
+}
+
+// CreateHatchPattern creates a repeating pattern of parallel lines, spacing apart, at the given rotation.
+func CreateHatchPattern(spacing, lineWidth, rotation float64, color blcolor.Color) (*Pattern, error) {
+	pattern, err := CreateTilePattern(spacing, spacing, func(context *Context, w, h float64) {
+		context.SetSourceColor(color)
+		context.SetLineWidth(lineWidth)
+		context.MoveTo(0, h/2)
+		context.LineTo(w, h/2)
+		context.Stroke()
+	})
+	if err != nil {
+		return nil, err
+	}
+	pattern.SetTransform(0, 0, rotation, 1)
+	return pattern, nil
+}
+
+// CreateDotPattern creates a repeating grid of dots, spacing apart.
+func CreateDotPattern(spacing, radius float64, color blcolor.Color) (*Pattern, error) {
+	return CreateTilePattern(spacing, spacing, func(context *Context, w, h float64) {
+		context.SetSourceColor(color)
+		context.FillCircle(w/2, h/2, radius)
+	})
+}
+
+// CreateBrickPattern creates a repeating pattern of mortar lines for w x h bricks, with alternate rows offset by half a brick.
+func CreateBrickPattern(w, h, lineWidth float64, color blcolor.Color) (*Pattern, error) {
+	return CreateTilePattern(w, h*2, func(context *Context, tw, th float64) {
+		context.SetSourceColor(color)
+		context.SetLineWidth(lineWidth)
+		// lines on the tile edges are half drawn on each side and meet up when the tile repeats.
+		for _, y := range []float64{0, h, h * 2} {
+			context.MoveTo(0, y)
+			context.LineTo(w, y)
+		}
+		context.MoveTo(0, 0)
+		context.LineTo(0, h)
+		context.MoveTo(w, 0)
+		context.LineTo(w, h)
+		context.MoveTo(w/2, h)
+		context.LineTo(w/2, h*2)
+		context.Stroke()
+	})
+}
@@ -0,0 +1,71 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+// Pixel data is read and written in the byte order of little endian machines, as elsewhere in this package.
+// Formats with color and alpha hold alpha-premultiplied values. Formats without alpha read as opaque.
+// A8 and A1 hold only alpha and read as black.
+
+// pixelIndex returns the index of the first byte of the pixel at x, y.
+func pixelIndex(format Format, stride, x, y int) int {
+	switch format {
+	case FormatA8:
+		return y*stride + x
+	case FormatA1:
+		return y*stride + x/8
+	case FormatRGB16565:
+		return y*stride + x*2
+	}
+	return y*stride + x*4
+}
+
+// readPixel returns the r, g, b, a values of the pixel at x, y as bytes.
+func readPixel(data []byte, format Format, stride, x, y int) (byte, byte, byte, byte) {
+	i := pixelIndex(format, stride, x, y)
+	switch format {
+	case FormatRGB24:
+		return data[i+2], data[i+1], data[i], 255
+	case FormatA8:
+		return 0, 0, 0, data[i]
+	case FormatA1:
+		if data[i]&(1<<(x%8)) != 0 {
+			return 0, 0, 0, 255
+		}
+		return 0, 0, 0, 0
+	case FormatRGB16565:
+		v := uint16(data[i]) | uint16(data[i+1])<<8
+		r, g, b := byte(v>>11&0x1f), byte(v>>5&0x3f), byte(v&0x1f)
+		return r<<3 | r>>2, g<<2 | g>>4, b<<3 | b>>2, 255
+	case FormatRGB30:
+		v := uint32(data[i]) | uint32(data[i+1])<<8 | uint32(data[i+2])<<16 | uint32(data[i+3])<<24
+		return byte(v >> 22), byte(v >> 12), byte(v >> 2), 255
+	}
+	return data[i+2], data[i+1], data[i], data[i+3]
+}
+
+// writePixel sets the pixel at x, y from r, g, b, a byte values.
+// Channels that the format does not have are ignored. A1 pixels are set when alpha is at least 128.
+func writePixel(data []byte, format Format, stride, x, y int, r, g, b, a byte) {
+	i := pixelIndex(format, stride, x, y)
+	switch format {
+	case FormatRGB24:
+		data[i], data[i+1], data[i+2], data[i+3] = b, g, r, 255
+	case FormatA8:
+		data[i] = a
+	case FormatA1:
+		if a >= 128 {
+			data[i] |= 1 << (x % 8)
+		} else {
+			data[i] &^= 1 << (x % 8)
+		}
+	case FormatRGB16565:
+		v := uint16(r>>3)<<11 | uint16(g>>2)<<5 | uint16(b>>3)
+		data[i], data[i+1] = byte(v), byte(v>>8)
+	case FormatRGB30:
+		// expand each 8 bit channel to 10 bits.
+		r10, g10, b10 := uint32(r)<<2|uint32(r)>>6, uint32(g)<<2|uint32(g)>>6, uint32(b)<<2|uint32(b)>>6
+		v := r10<<20 | g10<<10 | b10
+		data[i], data[i+1], data[i+2], data[i+3] = byte(v), byte(v>>8), byte(v>>16), byte(v>>24)
+	default:
+		data[i], data[i+1], data[i+2], data[i+3] = b, g, r, a
+	}
+}
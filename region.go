@@ -0,0 +1,273 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+// #include <cairo/cairo.h>
+import "C"
+
+import (
+	"iter"
+	"math"
+	"runtime"
+)
+
+// Region is a set of whole pixels, stored as a list of non-overlapping integer rectangles.
+// Regions are useful for tracking dirty areas that need redrawing and for simple hit-testing.
+// Rectangles given to a region are rounded outward to whole pixels.
+type Region struct {
+	region *C.cairo_region_t
+	// owned is true when this value holds a reference that Destroy releases.
+	owned bool
+}
+
+// newRegion wraps a native region, taking ownership of its reference.
+func newRegion(native *C.cairo_region_t) *Region {
+	r := &Region{region: native, owned: true}
+	liveRegions.Add(1)
+	trackObject(r)
+	runtime.SetFinalizer(r, (*Region).Destroy)
+	return r
+}
+
+// toRectangleInt converts a rectangle to the smallest integer rectangle containing it.
+func toRectangleInt(rect Rectangle) C.cairo_rectangle_int_t {
+	x0 := math.Floor(rect.X)
+	y0 := math.Floor(rect.Y)
+	x1 := math.Ceil(rect.X + rect.Width)
+	y1 := math.Ceil(rect.Y + rect.Height)
+	return C.cairo_rectangle_int_t{
+		x:      C.int(x0),
+		y:      C.int(y0),
+		width:  C.int(x1 - x0),
+		height: C.int(y1 - y0),
+	}
+}
+
+func fromRectangleInt(rect C.cairo_rectangle_int_t) Rectangle {
+	return Rectangle{float64(rect.x), float64(rect.y), float64(rect.width), float64(rect.height)}
+}
+
+////////////////////
+// Creation
+////////////////////
+
+// NewRegion creates an empty region.
+func NewRegion() *Region {
+	return newRegion(C.cairo_region_create())
+}
+
+// NewRegionFromRectangle creates a region covering the given rectangle.
+func NewRegionFromRectangle(rect Rectangle) *Region {
+	nativeRect := toRectangleInt(rect)
+	return newRegion(C.cairo_region_create_rectangle(&nativeRect))
+}
+
+// NewRegionFromRectangles creates a region covering the union of the given rectangles.
+func NewRegionFromRectangles(rects []Rectangle) *Region {
+	if len(rects) == 0 {
+		return NewRegion()
+	}
+	nativeRects := make([]C.cairo_rectangle_int_t, len(rects))
+	for i, rect := range rects {
+		nativeRects[i] = toRectangleInt(rect)
+	}
+	return newRegion(C.cairo_region_create_rectangles(&nativeRects[0], C.int(len(nativeRects))))
+}
+
+// ClipRegion returns the current clip as a region.
+// It returns StatusClipNotRepresentable if the clip is not made up of rectangles in user space.
+func (c *Context) ClipRegion() (*Region, error) {
+	rects, err := c.ClipRectangleList()
+	if err != nil {
+		return nil, err
+	}
+	return NewRegionFromRectangles(rects), nil
+}
+
+// Region adds the rectangles of a region to the current path.
+// Fill it to paint the region, or clip to it to restrict drawing to the region.
+func (c *Context) Region(region *Region) {
+	for rect := range region.All() {
+		c.Rectangle(rect.X, rect.Y, rect.Width, rect.Height)
+	}
+}
+
+// Copy creates a new region with the same contents as this region.
+func (r *Region) Copy() *Region {
+	defer runtime.KeepAlive(r)
+	return newRegion(C.cairo_region_copy(r.region))
+}
+
+// Reference returns a new Region owning another reference to this region.
+// Both values must be destroyed. Changes to either are seen by both.
+func (r *Region) Reference() *Region {
+	defer runtime.KeepAlive(r)
+	return newRegion(C.cairo_region_reference(r.region))
+}
+
+// Destroy releases this value's reference to the region. Calling it more than once does nothing.
+func (r *Region) Destroy() {
+	if !r.owned {
+		return
+	}
+	r.owned = false
+	runtime.SetFinalizer(r, nil)
+	C.cairo_region_destroy(r.region)
+	liveRegions.Add(-1)
+	untrackObject(r)
+}
+
+// Status returns an error if the region is in an error state, such as after running out of memory.
+func (r *Region) Status() error {
+	defer runtime.KeepAlive(r)
+	if status := Status(C.cairo_region_status(r.region)); status != StatusSuccess {
+		return status
+	}
+	return nil
+}
+
+////////////////////
+// Queries
+////////////////////
+
+// Equal returns whether two regions cover the same pixels.
+func (r *Region) Equal(other *Region) bool {
+	defer runtime.KeepAlive(r)
+	defer runtime.KeepAlive(other)
+	return C.cairo_region_equal(r.region, other.region) != 0
+}
+
+// IsEmpty returns whether the region covers no pixels.
+func (r *Region) IsEmpty() bool {
+	defer runtime.KeepAlive(r)
+	return C.cairo_region_is_empty(r.region) != 0
+}
+
+// GetExtents returns the bounding rectangle of the region.
+func (r *Region) GetExtents() Rectangle {
+	defer runtime.KeepAlive(r)
+	var extents C.cairo_rectangle_int_t
+	C.cairo_region_get_extents(r.region, &extents)
+	return fromRectangleInt(extents)
+}
+
+// NumRectangles returns the number of rectangles the region is made of.
+func (r *Region) NumRectangles() int {
+	defer runtime.KeepAlive(r)
+	return int(C.cairo_region_num_rectangles(r.region))
+}
+
+// GetRectangle returns the nth rectangle of the region.
+func (r *Region) GetRectangle(n int) Rectangle {
+	defer runtime.KeepAlive(r)
+	var rect C.cairo_rectangle_int_t
+	C.cairo_region_get_rectangle(r.region, C.int(n), &rect)
+	return fromRectangleInt(rect)
+}
+
+// All returns an iterator over the rectangles of the region.
+func (r *Region) All() iter.Seq[Rectangle] {
+	return func(yield func(Rectangle) bool) {
+		count := r.NumRectangles()
+		for i := 0; i < count; i++ {
+			if !yield(r.GetRectangle(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Rectangles returns the rectangles of the region as a slice.
+func (r *Region) Rectangles() []Rectangle {
+	rects := make([]Rectangle, 0, r.NumRectangles())
+	for rect := range r.All() {
+		rects = append(rects, rect)
+	}
+	return rects
+}
+
+// ContainsPoint returns whether the pixel containing the point x, y is in the region.
+func (r *Region) ContainsPoint(x, y float64) bool {
+	defer runtime.KeepAlive(r)
+	return C.cairo_region_contains_point(r.region, C.int(math.Floor(x)), C.int(math.Floor(y))) != 0
+}
+
+// ContainsRectangle returns whether the rectangle is inside, outside, or partly inside the region.
+func (r *Region) ContainsRectangle(rect Rectangle) RegionOverlap {
+	defer runtime.KeepAlive(r)
+	nativeRect := toRectangleInt(rect)
+	return RegionOverlap(C.cairo_region_contains_rectangle(r.region, &nativeRect))
+}
+
+////////////////////
+// Operations
+////////////////////
+
+// Translate moves the region by dx, dy pixels.
+func (r *Region) Translate(dx, dy int) {
+	defer runtime.KeepAlive(r)
+	C.cairo_region_translate(r.region, C.int(dx), C.int(dy))
+}
+
+// regionResult converts the status of a region operation to an error.
+func regionResult(status C.cairo_status_t) error {
+	if s := Status(status); s != StatusSuccess {
+		return s
+	}
+	return nil
+}
+
+// Union adds the other region to this region.
+func (r *Region) Union(other *Region) error {
+	defer runtime.KeepAlive(r)
+	defer runtime.KeepAlive(other)
+	return regionResult(C.cairo_region_union(r.region, other.region))
+}
+
+// UnionRectangle adds the rectangle to this region.
+func (r *Region) UnionRectangle(rect Rectangle) error {
+	defer runtime.KeepAlive(r)
+	nativeRect := toRectangleInt(rect)
+	return regionResult(C.cairo_region_union_rectangle(r.region, &nativeRect))
+}
+
+// Intersect sets this region to the pixels that are in both this and the other region.
+func (r *Region) Intersect(other *Region) error {
+	defer runtime.KeepAlive(r)
+	defer runtime.KeepAlive(other)
+	return regionResult(C.cairo_region_intersect(r.region, other.region))
+}
+
+// IntersectRectangle sets this region to the pixels that are in both this region and the rectangle.
+func (r *Region) IntersectRectangle(rect Rectangle) error {
+	defer runtime.KeepAlive(r)
+	nativeRect := toRectangleInt(rect)
+	return regionResult(C.cairo_region_intersect_rectangle(r.region, &nativeRect))
+}
+
+// Subtract removes the other region from this region.
+func (r *Region) Subtract(other *Region) error {
+	defer runtime.KeepAlive(r)
+	defer runtime.KeepAlive(other)
+	return regionResult(C.cairo_region_subtract(r.region, other.region))
+}
+
+// SubtractRectangle removes the rectangle from this region.
+func (r *Region) SubtractRectangle(rect Rectangle) error {
+	defer runtime.KeepAlive(r)
+	nativeRect := toRectangleInt(rect)
+	return regionResult(C.cairo_region_subtract_rectangle(r.region, &nativeRect))
+}
+
+// Xor sets this region to the pixels that are in either this or the other region, but not both.
+func (r *Region) Xor(other *Region) error {
+	defer runtime.KeepAlive(r)
+	defer runtime.KeepAlive(other)
+	return regionResult(C.cairo_region_xor(r.region, other.region))
+}
+
+// XorRectangle sets this region to the pixels that are in either this region or the rectangle, but not both.
+func (r *Region) XorRectangle(rect Rectangle) error {
+	defer runtime.KeepAlive(r)
+	nativeRect := toRectangleInt(rect)
+	return regionResult(C.cairo_region_xor_rectangle(r.region, &nativeRect))
+}
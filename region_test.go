@@ -0,0 +1,57 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+import "testing"
+
+func TestRegion(t *testing.T) {
+	region := NewRegionFromRectangle(Rectangle{10.5, 10, 20, 20})
+	defer region.Destroy()
+	extents := region.GetExtents()
+	if extents != (Rectangle{10, 10, 21, 20}) {
+		t.Errorf("Expected extents rounded out to {10 10 21 20}, got %v\n", extents)
+	}
+	if !region.ContainsPoint(15, 15) || region.ContainsPoint(5, 5) {
+		t.Errorf("Expected region to contain 15, 15 and not 5, 5\n")
+	}
+
+	if err := region.UnionRectangle(Rectangle{40, 10, 10, 10}); err != nil {
+		t.Errorf("Expected no error in union, got %s\n", err)
+	}
+	// regions are stored in horizontal bands, so the taller rectangle is split in two.
+	if region.NumRectangles() != 3 {
+		t.Errorf("Expected 3 rectangles, got %d\n", region.NumRectangles())
+	}
+	if overlap := region.ContainsRectangle(Rectangle{25, 15, 20, 2}); overlap != RegionOverlapPart {
+		t.Errorf("Expected overlap %v, got %v\n", RegionOverlapPart, overlap)
+	}
+
+	other := NewRegionFromRectangles([]Rectangle{{0, 0, 100, 15}})
+	defer other.Destroy()
+	copied := region.Copy()
+	defer copied.Destroy()
+	copied.Intersect(other)
+	if copied.GetExtents() != (Rectangle{10, 10, 40, 5}) {
+		t.Errorf("Expected intersection extents {10 10 40 5}, got %v\n", copied.GetExtents())
+	}
+	copied.Subtract(other)
+	if !copied.IsEmpty() {
+		t.Errorf("Expected region to be empty after subtracting, got %v\n", copied.Rectangles())
+	}
+	copied.Xor(region)
+	if !copied.Equal(region) {
+		t.Errorf("Expected xor with an empty region to equal the other region\n")
+	}
+
+	_, context := createContext()
+	context.Region(region)
+	context.Clip()
+	clip, err := context.ClipRegion()
+	if err != nil {
+		t.Errorf("Expected no error getting clip region, got %s\n", err)
+		return
+	}
+	defer clip.Destroy()
+	if !clip.Equal(region) {
+		t.Errorf("Expected clip region %v to equal region %v\n", clip.Rectangles(), region.Rectangles())
+	}
+}
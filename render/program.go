@@ -64,13 +64,25 @@ func (p *Program) Seconds() int {
 }
 
 // Render coordinates the rendering of all scenes in this Program.
+// If a frame cannot be rendered, the error is printed to stderr. Use TryRender to handle the error instead.
 func (p *Program) Render(frames string) {
+	if err := p.TryRender(frames); err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to render program:", err)
+	}
+}
+
+// TryRender renders all scenes in this Program, stopping at the first frame that cannot be drawn or saved.
+func (p *Program) TryRender(frames string) error {
 	initProgress()
 	os.RemoveAll(frames)
-	os.MkdirAll(frames, 0755)
+	if err := os.MkdirAll(frames, 0755); err != nil {
+		return err
+	}
 
 	surface := cairo.NewSurface(int(p.Width), int(p.Height))
+	defer surface.Destroy()
 	context := cairo.NewContext(surface)
+	defer context.Destroy()
 	currentFrame := 0
 	totalFrames := float64(p.TotalFrames())
 
@@ -81,12 +93,14 @@ func (p *Program) Render(frames string) {
 			totalPercent := float64(currentFrame) / totalFrames
 			sceneName := fmt.Sprintf("scene %d", f)
 			setProgress(sceneName, currentFrame, p.TotalFrames(), totalPercent)
-			scene.FrameFunc(context, p.Width, p.Height, percent)
-			surface.WriteToPNG(fmt.Sprintf("%s/frame_%04d.png", frames, currentFrame))
+			if err := drawFrame(context, p.Width, p.Height, percent, scene.FrameFunc, frames, currentFrame); err != nil {
+				return err
+			}
 			currentFrame++
 		}
 	}
 	setComplete()
+	return nil
 }
 
 // RenderAndPlayVideo renders the program to a video file using the given frames directory and output filename
@@ -101,8 +115,24 @@ func (p *Program) RenderVideo(frames, fileName string) {
 	ConvertToVideo(frames, fileName, p.Width, p.Height, p.FPS, p.Seconds(), true)
 }
 
+// TryRenderVideo renders the program to a video file using the given frames directory and output filename
+func (p *Program) TryRenderVideo(frames, fileName string) error {
+	if err := p.TryRender(frames); err != nil {
+		return err
+	}
+	return TryConvertToVideo(frames, fileName, p.Width, p.Height, p.FPS, p.Seconds(), true)
+}
+
 // RenderGif renders the program to a video file using the given frames directory and output filename
 func (p *Program) RenderGif(frames, fileName string) {
 	p.Render(frames)
 	FfmpegToGIF(frames, fileName, p.FPS)
 }
+
+// TryRenderGif renders the program to a gif file using the given frames directory and output filename
+func (p *Program) TryRenderGif(frames, fileName string) error {
+	if err := p.TryRender(frames); err != nil {
+		return err
+	}
+	return TryFfmpegToGIF(frames, fileName, p.FPS)
+}
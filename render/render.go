@@ -26,43 +26,91 @@ func CreateAndViewImage(width, height float64, path string, frameFunc FrameFunc,
 }
 
 // Image sets up the rendering of a single image.
+// If the image cannot be rendered, the error is printed to stderr. Use TryImage to handle the error instead.
 func Image(width, height float64, path string, frameFunc FrameFunc, percent float64) {
+	if err := TryImage(width, height, path, frameFunc, percent); err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to render image:", err)
+	}
+}
+
+// TryImage renders a single image, returning an error if it cannot be drawn or saved.
+func TryImage(width, height float64, path string, frameFunc FrameFunc, percent float64) error {
 	fmt.Println("Generating image...")
 	surface := cairo.NewSurface(int(width), int(height))
+	defer surface.Destroy()
 	context := cairo.NewContext(surface)
+	defer context.Destroy()
 	frameFunc(context, width, height, percent)
-	checkOutDir(path)
-	surface.WriteToPNG(path)
+	if err := context.Err(); err != nil {
+		return err
+	}
+	if err := checkOutDir(path); err != nil {
+		return err
+	}
+	if err := surface.WriteToPNG(path); err != nil {
+		return err
+	}
 	fmt.Println("Image complete!")
-	data, _ := os.Stat(path)
+	data, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
 	fmt.Println("File:", path)
 	fmt.Printf("Resolution: %dx%d\n", int(width), int(height))
 	fmt.Printf("Size: %0.2f kb\n", float64(data.Size())/1000)
+	return nil
 }
 
-func checkOutDir(path string) {
+func checkOutDir(path string) error {
 	dir := filepath.Dir(path)
 	if _, err := os.Stat(dir); err != nil {
 		if errors.Is(err, os.ErrNotExist) {
-			os.MkdirAll(dir, 0755)
+			return os.MkdirAll(dir, 0755)
 		}
 	}
+	return nil
 }
 
 // Frames sets up the renderin of a series of frames.
+// If a frame cannot be rendered, the error is printed to stderr. Use TryFrames to handle the error instead.
 func Frames(renderName string, width, height float64, numFrames int, frames string, frameFunc FrameFunc) {
+	if err := TryFrames(renderName, width, height, numFrames, frames, frameFunc); err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to render frames:", err)
+	}
+}
+
+// TryFrames renders a series of frames, stopping at the first frame that cannot be drawn or saved.
+func TryFrames(renderName string, width, height float64, numFrames int, frames string, frameFunc FrameFunc) error {
 	initProgress()
 	os.RemoveAll(frames)
-	os.MkdirAll(frames, 0755)
+	if err := os.MkdirAll(frames, 0755); err != nil {
+		return err
+	}
 	surface := cairo.NewSurface(int(width), int(height))
+	defer surface.Destroy()
 	context := cairo.NewContext(surface)
+	defer context.Destroy()
 	for frame := 0; frame < numFrames; frame++ {
 		percent := float64(frame) / float64(numFrames)
 		setProgress(renderName, frame, numFrames, percent)
-		frameFunc(context, width, height, percent)
-		surface.WriteToPNG(fmt.Sprintf("%s/frame_%04d.png", frames, frame))
+		if err := drawFrame(context, width, height, percent, frameFunc, frames, frame); err != nil {
+			return err
+		}
 	}
 	setComplete()
+	return nil
+}
+
+// drawFrame draws one frame and saves it as a numbered png in the frames folder.
+func drawFrame(context *cairo.Context, width, height, percent float64, frameFunc FrameFunc, frames string, frame int) error {
+	frameFunc(context, width, height, percent)
+	if err := context.Err(); err != nil {
+		return fmt.Errorf("frame %d: %w", frame, err)
+	}
+	if err := context.Surface.WriteToPNG(fmt.Sprintf("%s/frame_%04d.png", frames, frame)); err != nil {
+		return fmt.Errorf("frame %d: %w", frame, err)
+	}
+	return nil
 }
 
 // CleanFrames cleans out frames.
@@ -72,43 +120,72 @@ func CleanFrames(frames string) {
 }
 
 // FrameRange renders a range of frames
+// If a frame cannot be rendered, the error is printed to stderr. Use TryFrameRange to handle the error instead.
 func FrameRange(width, height float64, numFrames, start, end int, frames string, frameFunc FrameFunc) {
+	if err := TryFrameRange(width, height, numFrames, start, end, frames, frameFunc); err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to render frames:", err)
+	}
+}
+
+// TryFrameRange renders a range of frames, stopping at the first frame that cannot be drawn or saved.
+func TryFrameRange(width, height float64, numFrames, start, end int, frames string, frameFunc FrameFunc) error {
 	initProgress()
 	surface := cairo.NewSurface(int(width), int(height))
+	defer surface.Destroy()
 	context := cairo.NewContext(surface)
+	defer context.Destroy()
 	for frame := start; frame <= end; frame++ {
 		percent := float64(frame) / float64(numFrames)
 		fr := fmt.Sprintf("range: %d-%d", start, end)
 		setProgress(fr, frame, numFrames, percent)
-		frameFunc(context, width, height, percent)
-		surface.WriteToPNG(fmt.Sprintf("%s/frame_%04d.png", frames, frame))
+		if err := drawFrame(context, width, height, percent, frameFunc, frames, frame); err != nil {
+			return err
+		}
 	}
 	setComplete()
+	return nil
 }
 
 // SpriteSheet sets up the rendering of a sprite sheet.
+// If the sheet cannot be rendered, the error is printed to stderr. Use TrySpriteSheet to handle the error instead.
 func SpriteSheet(width, height float64, bg blcolor.Color, path string, numFrames int, frameFunc FrameFunc) {
-	// todo: create an intermediate surface of sizw width*height. Pass its context to the frameFunc.
-	// Then draw it to the correct location in the main surface.
-	// This will allow for functions that affect the whole context to work in frameFunc, such as:
-	// clearing functions, translateCenter
-	// as well as limiting the scope of functions that draw outside bounds.
+	if err := TrySpriteSheet(width, height, bg, path, numFrames, frameFunc); err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to render sprite sheet:", err)
+	}
+}
+
+// TrySpriteSheet renders a sprite sheet, returning an error if a frame cannot be drawn or the sheet cannot be saved.
+func TrySpriteSheet(width, height float64, bg blcolor.Color, path string, numFrames int, frameFunc FrameFunc) error {
+	// each frame is drawn on its own recording surface, then painted into place on the sheet.
+	// this lets frameFunc clear or transform the whole context, and clips drawing to the frame.
 	initProgress()
 	x := 0.0
 	y := 0.0
 	nf := float64(numFrames)
 	size := math.Ceil(math.Sqrt(nf))
 	surface := cairo.NewSurface(int(width*size), int(height*size))
+	defer surface.Destroy()
 	context := cairo.NewContext(surface)
+	defer context.Destroy()
 	context.ClearColor(bg)
 
 	for i := 0.0; i < nf; i++ {
-		context.Save()
-		context.Translate(x, y)
+		frame, err := cairo.NewRecordingSurface(cairo.ContentColorAlpha, 0, 0, width, height)
+		if err != nil {
+			return err
+		}
+		frameContext := cairo.NewContext(frame)
 		percent := i / float64(numFrames)
 		setProgress("sprite sheet", int(i), numFrames, percent)
-		frameFunc(context, width, height, percent)
-		context.Restore()
+		frameFunc(frameContext, width, height, percent)
+		err = frameContext.Err()
+		frameContext.Destroy()
+		if err != nil {
+			frame.Destroy()
+			return fmt.Errorf("frame %d: %w", int(i), err)
+		}
+		context.Replay(frame, x, y, 1)
+		frame.Destroy()
 
 		x += width
 		if x >= size*width {
@@ -116,8 +193,11 @@ func SpriteSheet(width, height float64, bg blcolor.Color, path string, numFrames
 			y += height
 		}
 	}
-	surface.WriteToPNG(path)
+	if err := surface.WriteToPNG(path); err != nil {
+		return err
+	}
 	setComplete()
+	return nil
 }
 
 func initProgress() {
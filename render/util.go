@@ -10,6 +10,9 @@ import (
 	"strconv"
 )
 
+// Each helper here that runs an external tool exits the program if it fails.
+// The Try version of each helper returns the error instead.
+
 var imageRenderType = "png"
 
 // UseBMP sets whether or not images should be rendered as bmp or png
@@ -23,15 +26,32 @@ func UseBMP(value bool) {
 
 // MakeGIF creates an animated gif with the given tool.
 func MakeGIF(tool, folder, outFileName string, w, h float64, fps, seconds int) {
+	if err := TryMakeGIF(tool, folder, outFileName, w, h, fps, seconds); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// TryMakeGIF creates an animated gif with the given tool, either "convert" or "ffmpeg".
+func TryMakeGIF(tool, folder, outFileName string, w, h float64, fps, seconds int) error {
 	fmt.Println("Converting to GIF...")
 	os.RemoveAll(outFileName)
-	if tool == "convert" {
-		ConvertToGIF(folder, outFileName, fps)
-	} else if tool == "ffmpeg" {
-		FfmpegToGIF(folder, outFileName, fps)
+	var err error
+	switch tool {
+	case "convert":
+		err = TryConvertToGIF(folder, outFileName, fps)
+	case "ffmpeg":
+		err = TryFfmpegToGIF(folder, outFileName, fps)
+	default:
+		err = fmt.Errorf("unknown gif tool %q", tool)
+	}
+	if err != nil {
+		return err
 	}
 	fmt.Println("GIF complete!")
-	data, _ := os.Stat(outFileName)
+	data, err := os.Stat(outFileName)
+	if err != nil {
+		return err
+	}
 	fmt.Println("File:", outFileName)
 	fmt.Printf("Resolution: %dx%d\n", int(w), int(h))
 	fmt.Printf("FPS: %d\n", int(fps))
@@ -39,56 +59,84 @@ func MakeGIF(tool, folder, outFileName string, w, h float64, fps, seconds int) {
 	seconds = seconds % 60
 	fmt.Printf("Time: %d:%02d", minutes, seconds)
 	fmt.Printf("Size: %dkb\n", data.Size()/1000)
+	return nil
 }
 
 // MakeMontage creates an animated gif with the given tool.
 // Set cols to 0 for auto sizing
 func MakeMontage(cols int, folder, outFileName string) {
+	if err := TryMakeMontage(cols, folder, outFileName); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// TryMakeMontage creates a montage of the images in a folder using imagemagick montage.
+// Set cols to 0 for auto sizing
+func TryMakeMontage(cols int, folder, outFileName string) error {
 	fmt.Println("Making montage...")
 	os.RemoveAll(outFileName)
 	path := folder + "/*." + imageRenderType
 	cmd := exec.Command("montage", path, "-tile", strconv.Itoa(cols), "-geometry", "+1+1", outFileName)
 	err := cmd.Run()
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 	fmt.Println("Montage complete!")
-	data, _ := os.Stat(outFileName)
+	data, err := os.Stat(outFileName)
+	if err != nil {
+		return err
+	}
 	fmt.Println("File:", outFileName)
 	fmt.Printf("Size: %dkb\n", data.Size()/1000)
+	return nil
 }
 
 // ConvertToGIF converts a folder of pngs into an animated gif using imagemagick convert.
 func ConvertToGIF(folder, outFileName string, fps int) {
+	if err := TryConvertToGIF(folder, outFileName, fps); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// TryConvertToGIF converts a folder of pngs into an animated gif using imagemagick convert.
+func TryConvertToGIF(folder, outFileName string, fps int) error {
 	delay := fmt.Sprintf("%f", 1000.0/float64(fps)/10.0)
 	path := folder + "/*." + imageRenderType
 	cmd := exec.Command("convert", "-delay", delay, "-layers", "Optimize", path, outFileName)
-	err := cmd.Run()
-	if err != nil {
-		log.Fatal(err)
-	}
+	return cmd.Run()
 }
 
 // FfmpegToGIF converts a folder of pngs into an animated gif using ffmpeg.
 func FfmpegToGIF(folder, outFileName string, fps int) {
+	if err := TryFfmpegToGIF(folder, outFileName, fps); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// TryFfmpegToGIF converts a folder of pngs into an animated gif using ffmpeg.
+func TryFfmpegToGIF(folder, outFileName string, fps int) error {
 	path := folder + "/frame_%04d." + imageRenderType
 	fpsArg := fmt.Sprintf("%d", fps)
 
 	paletteCmd := exec.Command("ffmpeg", "-y", "-i", path, "-vf", "palettegen", "palette.png")
 	err := paletteCmd.Run()
 	if err != nil {
-		log.Fatalf("Could not create palette: %s", err)
+		return fmt.Errorf("could not create palette: %w", err)
 	}
 
 	outCmd := exec.Command("ffmpeg", "-y", "-framerate", fpsArg, "-i", path, "-i", "palette.png", "-filter_complex", "paletteuse", outFileName)
-	err = outCmd.Run()
-	if err != nil {
-		log.Fatal(err)
-	}
+	return outCmd.Run()
 }
 
 // ConvertToVideo converts a folder of pngs into an mp4 video file. Requires ffmpeg.
 func ConvertToVideo(folder, outFileName string, w, h float64, fps, seconds int, verbose bool) {
+	if err := TryConvertToVideo(folder, outFileName, w, h, fps, seconds, verbose); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// TryConvertToVideo converts a folder of pngs into an mp4 video file. Requires ffmpeg.
+func TryConvertToVideo(folder, outFileName string, w, h float64, fps, seconds int, verbose bool) error {
 	if verbose {
 		fmt.Println("Converting to video...")
 	}
@@ -102,11 +150,14 @@ func ConvertToVideo(folder, outFileName string, w, h float64, fps, seconds int,
 		"-pix_fmt", "yuv420p", outFileName)
 	err := cmd.Run()
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 	if verbose {
 		fmt.Println("Video complete!")
-		data, _ := os.Stat(outFileName)
+		data, err := os.Stat(outFileName)
+		if err != nil {
+			return err
+		}
 		fmt.Println("File:", outFileName)
 		fmt.Printf("Resolution: %dx%d\n", int(w), int(h))
 		fmt.Printf("FPS: %d\n", fps)
@@ -116,10 +167,18 @@ func ConvertToVideo(folder, outFileName string, w, h float64, fps, seconds int,
 		fmt.Printf("Time: %d:%02d\n", minutes, seconds)
 		fmt.Printf("Size: %dkb\n", data.Size()/1000)
 	}
+	return nil
 }
 
 // MixAV mixes an audio and video file.
 func MixAV(videoFileName, audioFileName, outFileName string) {
+	if err := TryMixAV(videoFileName, audioFileName, outFileName); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// TryMixAV mixes an audio and video file.
+func TryMixAV(videoFileName, audioFileName, outFileName string) error {
 	cmd := exec.Command(
 		"ffmpeg", "-y",
 		"-i", videoFileName,
@@ -129,58 +188,80 @@ func MixAV(videoFileName, audioFileName, outFileName string) {
 		"-map", "1:a:0",
 		outFileName,
 	)
-	err := cmd.Run()
-	if err != nil {
-		log.Fatal(err)
-	}
+	return cmd.Run()
 }
 
 // ViewImage displays an image using installed image viewer.
 func ViewImage(imagePath string) {
-	cmd := exec.Command("bitlibImageViewer", imagePath)
-	err := cmd.Run()
-	if err != nil {
+	if err := TryViewImage(imagePath); err != nil {
 		log.Fatal(err)
 	}
 }
 
+// TryViewImage displays an image using installed image viewer.
+func TryViewImage(imagePath string) error {
+	cmd := exec.Command("bitlibImageViewer", imagePath)
+	return cmd.Run()
+}
+
 // ViewGif plays an animated gif using installed gif viewer
 func ViewGif(imagePath string) {
-	cmd := exec.Command("bitlibGifViewer", imagePath)
-	err := cmd.Run()
-	if err != nil {
+	if err := TryViewGif(imagePath); err != nil {
 		log.Fatal(err)
 	}
 }
 
+// TryViewGif plays an animated gif using installed gif viewer
+func TryViewGif(imagePath string) error {
+	cmd := exec.Command("bitlibGifViewer", imagePath)
+	return cmd.Run()
+}
+
 // VLC launches vlc to play a video
 func VLC(fileName string, loop bool) {
+	if err := TryVLC(fileName, loop); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// TryVLC launches vlc to play a video
+func TryVLC(fileName string, loop bool) error {
 	loopArg := ""
 	if loop {
 		loopArg = "--loop"
 	}
 	cmd := exec.Command("vlc", loopArg, fileName)
-	err := cmd.Run()
-	if err != nil {
-		log.Fatal(err)
-	}
+	return cmd.Run()
 }
 
 // PlayVideo launches an app to play a video
 func PlayVideo(fileName string) {
-	cmd := exec.Command("bitlibVideoPlayer", fileName)
-	err := cmd.Run()
-	if err != nil {
+	if err := TryPlayVideo(fileName); err != nil {
 		log.Fatal(err)
 	}
 }
 
+// TryPlayVideo launches an app to play a video
+func TryPlayVideo(fileName string) error {
+	cmd := exec.Command("bitlibVideoPlayer", fileName)
+	return cmd.Run()
+}
+
 // ParentDir returns the immediated directory name of the current working directory.
 func ParentDir() string {
-	wd, err := os.Getwd()
+	dir, err := TryParentDir()
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "Cannot get directory.")
 		os.Exit(1)
 	}
-	return filepath.Base(wd)
+	return dir
+}
+
+// TryParentDir returns the immediated directory name of the current working directory.
+func TryParentDir() (string, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Base(wd), nil
 }
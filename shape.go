@@ -0,0 +1,442 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strings"
+)
+
+// Shape is a filled or stroked path recorded by a ShapeRegistry.
+type Shape struct {
+	// ID is the id the shape was tagged with by BeginShape.
+	ID string
+	// Filled is true if the path was filled, false if it was stroked.
+	Filled bool
+	// Bounds is the device space bounding box of the ink of the shape.
+	Bounds Rectangle
+
+	// the user space path and the state needed to test it again.
+	path       *Path
+	matrix     Matrix
+	fillRule   FillRule
+	lineWidth  float64
+	lineCap    LineCap
+	lineJoin   LineJoin
+	miterLimit float64
+	dashes     []float64
+	dashOffset float64
+	// the path in device space with curves flattened, used for image maps.
+	outline *Path
+}
+
+// ShapeRegistry records the paths filled and stroked on a context while a shape id is set,
+// so they can later be found by point, topmost first, or exported as html image maps and svg links.
+// Points can also be tested against unions, intersections and differences of shapes by id.
+// Any drawing that ends in Fill, FillPreserve, Stroke or StrokePreserve is recorded,
+// including shape helpers such as FillRectangle and StrokeCircle. Text and painted images are not.
+type ShapeRegistry struct {
+	shapes []*Shape
+}
+
+// NewShapeRegistry creates an empty shape registry.
+func NewShapeRegistry() *ShapeRegistry {
+	return &ShapeRegistry{}
+}
+
+// SetShapeRegistry sets the registry that shapes drawn on this context are recorded in. nil stops recording.
+func (c *Context) SetShapeRegistry(registry *ShapeRegistry) {
+	c.shapes = registry
+}
+
+// GetShapeRegistry gets the registry that shapes drawn on this context are recorded in.
+func (c *Context) GetShapeRegistry() *ShapeRegistry {
+	return c.shapes
+}
+
+// BeginShape tags everything filled or stroked from now until EndShape with the given id,
+// recording it in the context's shape registry.
+func (c *Context) BeginShape(id string) {
+	c.shapeID = id
+}
+
+// EndShape stops tagging drawing with the current shape id.
+func (c *Context) EndShape() {
+	c.shapeID = ""
+}
+
+// recordShape adds the current path to the shape registry, if a registry and a shape id are set.
+func (c *Context) recordShape(filled bool) {
+	if c.shapes == nil || c.shapeID == "" {
+		return
+	}
+	path, err := c.CopyPath()
+	if err != nil {
+		return
+	}
+	shape := &Shape{
+		ID:         c.shapeID,
+		Filled:     filled,
+		path:       path,
+		matrix:     c.GetMatrix(),
+		fillRule:   c.GetFillRule(),
+		lineWidth:  c.GetLineWidth(),
+		lineCap:    c.GetLineCap(),
+		lineJoin:   c.GetLineJoin(),
+		miterLimit: c.GetMiterLimit(),
+	}
+	shape.dashes, shape.dashOffset = c.GetDash()
+
+	var left, top, right, bottom float64
+	if filled {
+		left, top, right, bottom = c.FillExtents()
+	} else {
+		left, top, right, bottom = c.StrokeExtents()
+	}
+	shape.Bounds = c.deviceBounds(left, top, right, bottom)
+
+	// with an identity matrix, the path is copied in device space.
+	c.Save()
+	c.IdentityMatrix()
+	shape.outline, err = c.CopyPathFlat()
+	c.Restore()
+	if err != nil {
+		return
+	}
+	c.shapes.shapes = append(c.shapes.shapes, shape)
+}
+
+// deviceBounds returns the device space bounding box of a user space rectangle.
+func (c *Context) deviceBounds(left, top, right, bottom float64) Rectangle {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, corner := range [][2]float64{{left, top}, {right, top}, {right, bottom}, {left, bottom}} {
+		x, y := c.UserToDevice(corner[0], corner[1])
+		minX, minY = math.Min(minX, x), math.Min(minY, y)
+		maxX, maxY = math.Max(maxX, x), math.Max(maxY, y)
+	}
+	return Rectangle{minX, minY, maxX - minX, maxY - minY}
+}
+
+////////////////////
+// Queries
+////////////////////
+
+// Shapes returns the recorded shapes in the order they were drawn, bottom first.
+func (r *ShapeRegistry) Shapes() []*Shape {
+	return r.shapes
+}
+
+// Clear removes all recorded shapes.
+func (r *ShapeRegistry) Clear() {
+	r.shapes = nil
+}
+
+// HitTest returns the ids of the shapes under the device space point x, y, topmost first.
+// Each id is only listed once, even if several of its shapes are hit.
+func (r *ShapeRegistry) HitTest(x, y float64) []string {
+	ids := []string{}
+	if len(r.shapes) == 0 {
+		return ids
+	}
+	context := newHitContext()
+	defer context.Destroy()
+
+	found := map[string]bool{}
+	for i := len(r.shapes) - 1; i >= 0; i-- {
+		shape := r.shapes[i]
+		if found[shape.ID] || !shape.Bounds.contains(x, y) {
+			continue
+		}
+		if shape.hit(context, x, y) {
+			found[shape.ID] = true
+			ids = append(ids, shape.ID)
+		}
+	}
+	return ids
+}
+
+// ShapeAt returns the id of the topmost shape under the device space point x, y,
+// and false if there is no shape there.
+func (r *ShapeRegistry) ShapeAt(x, y float64) (string, bool) {
+	ids := r.HitTest(x, y)
+	if len(ids) == 0 {
+		return "", false
+	}
+	return ids[0], true
+}
+
+// newHitContext creates a context for testing shapes against points. Nothing is drawn on it.
+func newHitContext() *Context {
+	surface := NewSurface(1, 1)
+	// the context keeps its own reference to the surface.
+	defer surface.Destroy()
+	return NewContext(surface)
+}
+
+// hitsID returns whether the device space point is inside any shape with the given id.
+func (r *ShapeRegistry) hitsID(context *Context, id string, x, y float64) bool {
+	for _, shape := range r.shapes {
+		if shape.ID == id && shape.Bounds.contains(x, y) && shape.hit(context, x, y) {
+			return true
+		}
+	}
+	return false
+}
+
+////////////////////
+// Booleans
+////////////////////
+
+// HitUnion returns whether the device space point x, y is inside a shape with any of the given ids.
+func (r *ShapeRegistry) HitUnion(x, y float64, ids ...string) bool {
+	context := newHitContext()
+	defer context.Destroy()
+	for _, id := range ids {
+		if r.hitsID(context, id, x, y) {
+			return true
+		}
+	}
+	return false
+}
+
+// HitIntersection returns whether the device space point x, y is inside a shape with each of the given ids.
+// It returns false if no ids are given.
+func (r *ShapeRegistry) HitIntersection(x, y float64, ids ...string) bool {
+	if len(ids) == 0 {
+		return false
+	}
+	context := newHitContext()
+	defer context.Destroy()
+	for _, id := range ids {
+		if !r.hitsID(context, id, x, y) {
+			return false
+		}
+	}
+	return true
+}
+
+// HitDifference returns whether the device space point x, y is inside a shape with the given id,
+// but not inside any shape with the excluded ids.
+func (r *ShapeRegistry) HitDifference(x, y float64, id string, excluded ...string) bool {
+	context := newHitContext()
+	defer context.Destroy()
+	if !r.hitsID(context, id, x, y) {
+		return false
+	}
+	for _, other := range excluded {
+		if r.hitsID(context, other, x, y) {
+			return false
+		}
+	}
+	return true
+}
+
+// inFillPaths returns whether the user space point x, y is inside each of the paths,
+// using the current fill rule and transform. The current path is left unchanged.
+func (c *Context) inFillPaths(x, y float64, paths []*Path) []bool {
+	current, err := c.CopyPath()
+	if err != nil {
+		return make([]bool, len(paths))
+	}
+	hits := make([]bool, len(paths))
+	for i, path := range paths {
+		c.NewPath()
+		c.AppendPath(path)
+		hits[i] = c.InFill(x, y)
+	}
+	c.NewPath()
+	c.AppendPath(current)
+	return hits
+}
+
+// InFillUnion returns whether the user space point x, y is inside any of the paths when filled,
+// using the current fill rule and transform. The current path is left unchanged.
+func (c *Context) InFillUnion(x, y float64, paths ...*Path) bool {
+	for _, hit := range c.inFillPaths(x, y, paths) {
+		if hit {
+			return true
+		}
+	}
+	return false
+}
+
+// InFillIntersection returns whether the user space point x, y is inside all of the paths when filled.
+// It returns false if no paths are given.
+func (c *Context) InFillIntersection(x, y float64, paths ...*Path) bool {
+	if len(paths) == 0 {
+		return false
+	}
+	for _, hit := range c.inFillPaths(x, y, paths) {
+		if !hit {
+			return false
+		}
+	}
+	return true
+}
+
+// InFillDifference returns whether the user space point x, y is inside path when filled,
+// but not inside any of the excluded paths.
+func (c *Context) InFillDifference(x, y float64, path *Path, excluded ...*Path) bool {
+	hits := c.inFillPaths(x, y, append([]*Path{path}, excluded...))
+	if !hits[0] {
+		return false
+	}
+	for _, hit := range hits[1:] {
+		if hit {
+			return false
+		}
+	}
+	return true
+}
+
+// contains returns whether the point is inside the rectangle, including its edges.
+func (r Rectangle) contains(x, y float64) bool {
+	return x >= r.X && x <= r.X+r.Width && y >= r.Y && y <= r.Y+r.Height
+}
+
+// hit rebuilds the shape on the given context and tests the device space point against it.
+func (s *Shape) hit(context *Context, x, y float64) bool {
+	context.NewPath()
+	context.SetMatrix(s.matrix)
+	context.AppendPath(s.path)
+	ux, uy := context.DeviceToUser(x, y)
+	if s.Filled {
+		context.SetFillRule(s.fillRule)
+		return context.InFill(ux, uy)
+	}
+	context.SetLineWidth(s.lineWidth)
+	context.SetLineCap(s.lineCap)
+	context.SetLineJoin(s.lineJoin)
+	context.SetMiterLimit(s.miterLimit)
+	if len(s.dashes) > 0 {
+		context.SetDash(s.dashes, len(s.dashes), s.dashOffset)
+	} else {
+		context.DisableDash()
+	}
+	return context.InStroke(ux, uy)
+}
+
+////////////////////
+// Image maps
+////////////////////
+
+// shapeHref returns the link for a shape id, using href if given, or "#id" otherwise.
+func shapeHref(id string, href func(id string) string) string {
+	if href == nil {
+		return "#" + id
+	}
+	return href(id)
+}
+
+// HTMLMap returns an html <map> element with the given name, with an <area> for each recorded shape,
+// linking to the url returned by href for its id. Shapes for which href returns "" are left out.
+// If href is nil, shapes link to "#" followed by their id.
+// Areas are listed topmost first, as browsers use the first area that contains a point.
+// Filled shapes become polygons. Stroked shapes become their bounding rectangles.
+func (r *ShapeRegistry) HTMLMap(name string, href func(id string) string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "<map name=\"%s\">\n", html.EscapeString(name))
+	for i := len(r.shapes) - 1; i >= 0; i-- {
+		shape := r.shapes[i]
+		link := shapeHref(shape.ID, href)
+		if link == "" {
+			continue
+		}
+		attrs := fmt.Sprintf("href=\"%s\" alt=\"%s\"", html.EscapeString(link), html.EscapeString(shape.ID))
+		if !shape.Filled {
+			b := shape.Bounds
+			fmt.Fprintf(&sb, "  <area shape=\"rect\" coords=\"%s,%s,%s,%s\" %s>\n",
+				formatCoord(b.X), formatCoord(b.Y), formatCoord(b.X+b.Width), formatCoord(b.Y+b.Height), attrs)
+			continue
+		}
+		for _, polygon := range shape.polygons() {
+			coords := make([]string, 0, len(polygon)*2)
+			for _, point := range polygon {
+				coords = append(coords, formatCoord(point[0]), formatCoord(point[1]))
+			}
+			fmt.Fprintf(&sb, "  <area shape=\"poly\" coords=\"%s\" %s>\n", strings.Join(coords, ","), attrs)
+		}
+	}
+	sb.WriteString("</map>\n")
+	return sb.String()
+}
+
+// SVGLinks returns an svg document of the given size with an invisible, linked path for each recorded shape,
+// to be laid over the rendered image. Links and leaving out shapes work as for HTMLMap.
+// Shapes are listed in drawing order, so the topmost shape receives the click.
+func (r *ShapeRegistry) SVGLinks(width, height float64, href func(id string) string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%s\" height=\"%s\" viewBox=\"0 0 %s %s\">\n",
+		formatCoord(width), formatCoord(height), formatCoord(width), formatCoord(height))
+	for _, shape := range r.shapes {
+		link := shapeHref(shape.ID, href)
+		if link == "" {
+			continue
+		}
+		var paint string
+		if shape.Filled {
+			fillRule := "nonzero"
+			if shape.fillRule == FillRuleEvenOdd {
+				fillRule = "evenodd"
+			}
+			paint = fmt.Sprintf("fill=\"transparent\" fill-rule=\"%s\"", fillRule)
+		} else {
+			// approximate the device space line width by the average scale of the matrix.
+			m := shape.matrix
+			scale := math.Sqrt(math.Abs(m.Xx*m.Yy - m.Xy*m.Yx))
+			paint = fmt.Sprintf("fill=\"none\" stroke=\"transparent\" stroke-width=\"%s\" pointer-events=\"stroke\"",
+				formatCoord(shape.lineWidth*scale))
+		}
+		fmt.Fprintf(&sb, "  <a href=\"%s\"><title>%s</title><path d=\"%s\" %s/></a>\n",
+			html.EscapeString(link), html.EscapeString(shape.ID), shape.svgPathData(), paint)
+	}
+	sb.WriteString("</svg>\n")
+	return sb.String()
+}
+
+// polygons returns the device space outline of the shape as one polygon per sub path,
+// leaving out any with fewer than three points.
+func (s *Shape) polygons() [][][2]float64 {
+	polygons := [][][2]float64{}
+	polygon := [][2]float64{}
+	flush := func() {
+		if len(polygon) >= 3 {
+			polygons = append(polygons, polygon)
+		}
+		polygon = [][2]float64{}
+	}
+	for _, segment := range s.outline.Segments {
+		switch segment.Type {
+		case PathMoveTo:
+			flush()
+			polygon = append(polygon, [2]float64{segment.Points[0].X, segment.Points[0].Y})
+		case PathLineTo:
+			polygon = append(polygon, [2]float64{segment.Points[0].X, segment.Points[0].Y})
+		}
+	}
+	flush()
+	return polygons
+}
+
+// svgPathData returns the device space outline of the shape as svg path data.
+func (s *Shape) svgPathData() string {
+	commands := []string{}
+	for _, segment := range s.outline.Segments {
+		switch segment.Type {
+		case PathMoveTo:
+			commands = append(commands, "M"+formatCoord(segment.Points[0].X)+" "+formatCoord(segment.Points[0].Y))
+		case PathLineTo:
+			commands = append(commands, "L"+formatCoord(segment.Points[0].X)+" "+formatCoord(segment.Points[0].Y))
+		case PathClosePath:
+			commands = append(commands, "Z")
+		}
+	}
+	return strings.Join(commands, " ")
+}
+
+// formatCoord formats a coordinate with at most two decimal places.
+func formatCoord(value float64) string {
+	return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.2f", value), "0"), ".")
+}
@@ -0,0 +1,109 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestShapeRegistry(t *testing.T) {
+	_, context := createContext()
+	registry := NewShapeRegistry()
+	context.SetShapeRegistry(registry)
+
+	context.BeginShape("back")
+	context.FillRectangle(50, 50, 200, 200)
+	context.EndShape()
+	context.FillRectangle(0, 0, 400, 400)
+	context.Translate(200, 200)
+	context.BeginShape("front")
+	context.SetLineWidth(10)
+	context.StrokeCircle(0, 0, 50)
+	context.EndShape()
+
+	if len(registry.Shapes()) != 2 {
+		t.Errorf("Expected 2 shapes, got %d\n", len(registry.Shapes()))
+	}
+	ids := registry.HitTest(250, 200)
+	if len(ids) != 2 || ids[0] != "front" || ids[1] != "back" {
+		t.Errorf("Expected [front back] at 250, 200, got %v\n", ids)
+	}
+	if id, ok := registry.ShapeAt(200, 200); !ok || id != "back" {
+		t.Errorf("Expected back inside the circle's stroke, got %q\n", id)
+	}
+	if _, ok := registry.ShapeAt(10, 10); ok {
+		t.Errorf("Expected no shape at 10, 10\n")
+	}
+
+	htmlMap := registry.HTMLMap("shapes", func(id string) string {
+		return "/" + id
+	})
+	if !strings.Contains(htmlMap, `<area shape="poly" coords="50,50,250,50,250,250,50,250" href="/back" alt="back">`) {
+		t.Errorf("Expected a polygon area for back, got\n%s\n", htmlMap)
+	}
+	front, back := strings.Index(htmlMap, "/front"), strings.Index(htmlMap, "/back")
+	if front < 0 || back < 0 {
+		t.Errorf("Expected areas for front and back, got\n%s\n", htmlMap)
+	} else if front > back {
+		t.Errorf("Expected front to be listed before back\n")
+	}
+	svg := registry.SVGLinks(400, 400, nil)
+	if !strings.Contains(svg, `<a href="#front">`) || !strings.Contains(svg, `stroke-width="10"`) {
+		t.Errorf("Expected a stroked link to #front, got\n%s\n", svg)
+	}
+}
+
+func TestShapeBooleans(t *testing.T) {
+	_, context := createContext()
+	registry := NewShapeRegistry()
+	context.SetShapeRegistry(registry)
+	context.BeginShape("left")
+	context.FillRectangle(0, 0, 200, 100)
+	context.EndShape()
+
+	// a reference records into the same registry with the same shape id.
+	context.BeginShape("right")
+	reference := context.Reference()
+	reference.FillRectangle(100, 0, 200, 100)
+	reference.Destroy()
+	context.EndShape()
+
+	if len(registry.Shapes()) != 2 || registry.Shapes()[1].ID != "right" {
+		t.Fatalf("Expected the reference to record shape right, got %d shapes\n", len(registry.Shapes()))
+	}
+	if !registry.HitUnion(50, 50, "left", "right") || !registry.HitUnion(250, 50, "left", "right") {
+		t.Errorf("Expected both ends to be in the union\n")
+	}
+	if registry.HitIntersection(50, 50, "left", "right") || !registry.HitIntersection(150, 50, "left", "right") {
+		t.Errorf("Expected only the overlap to be in the intersection\n")
+	}
+	if !registry.HitDifference(50, 50, "left", "right") || registry.HitDifference(150, 50, "left", "right") {
+		t.Errorf("Expected only the left end to be in the difference\n")
+	}
+
+	square := func(x, y float64) *Path {
+		path := &Path{}
+		path.MoveTo(x, y)
+		path.LineTo(x+100, y)
+		path.LineTo(x+100, y+100)
+		path.LineTo(x, y+100)
+		path.ClosePath()
+		return path
+	}
+	a, b := square(0, 200), square(50, 200)
+	context.NewPath()
+	context.MoveTo(10, 10)
+	context.LineTo(20, 20)
+	if !context.InFillUnion(125, 250, a, b) || context.InFillUnion(175, 150, a, b) {
+		t.Errorf("Expected union of paths to contain 125, 250 but not 175, 150\n")
+	}
+	if !context.InFillIntersection(75, 250, a, b) || context.InFillIntersection(25, 250, a, b) {
+		t.Errorf("Expected intersection of paths to contain 75, 250 but not 25, 250\n")
+	}
+	if !context.InFillDifference(25, 250, a, b) || context.InFillDifference(75, 250, a, b) {
+		t.Errorf("Expected difference of paths to contain 25, 250 but not 75, 250\n")
+	}
+	if x, y := context.GetCurrentPoint(); x != 20 || y != 20 {
+		t.Errorf("Expected the current path to be kept, got current point %f, %f\n", x, y)
+	}
+}
@@ -10,21 +10,33 @@ import "C"
 
 import (
 	"errors"
+	"runtime"
 	"unsafe"
 )
 
 // Surface represents a cairo surface
 type Surface struct {
 	surface *C.cairo_surface_t
+	// page size in points for vector surfaces, or region size for sub-surfaces.
+	pageWidth, pageHeight float64
+	// whether this value owns a reference, released by Destroy.
+	owned bool
+	// the value whose reference a borrowed surface uses, kept reachable so it is not finalized.
+	owner any
 }
 
 // NewSurface creates a new cairo surface.
 func NewSurface[T int | float64](width, height T) *Surface {
+	return NewSurfaceWithFormat(FormatARGB32, width, height)
+}
+
+// NewSurfaceWithFormat creates a new cairo surface with the given pixel format.
+// FormatA8 surfaces hold only alpha and are useful as masks with MaskSurface.
+// FormatRGB24 and FormatRGB30 surfaces have no alpha channel.
+func NewSurfaceWithFormat[T int | float64](format Format, width, height T) *Surface {
 	w := int(width)
 	h := int(height)
-	return &Surface{
-		C.cairo_image_surface_create(C.cairo_format_t(FormatARGB32), C.int(w), C.int(h)),
-	}
+	return newSurface(C.cairo_image_surface_create(C.cairo_format_t(format), C.int(w), C.int(h)), 0, 0)
 }
 
 // NewSurfaceFromPNG creates a new Surface struct from a png file.
@@ -35,87 +47,150 @@ func NewSurfaceFromPNG(filename string) (*Surface, error) {
 	surfaceNative := C.cairo_image_surface_create_from_png(cstr)
 	status := Status(C.cairo_surface_status(surfaceNative))
 	if status != StatusSuccess {
-		return nil, errors.New(status.String())
+		return nil, status
 	}
 
-	surface := &Surface{
-		surface: surfaceNative,
+	return newSurface(surfaceNative, 0, 0), nil
+}
+
+// newVectorSurface wraps a native vector surface, checking its status.
+func newVectorSurface(surfaceNative *C.cairo_surface_t, width, height float64) (*Surface, error) {
+	status := Status(C.cairo_surface_status(surfaceNative))
+	if status != StatusSuccess {
+		return nil, status
 	}
 
-	return surface, nil
+	return newSurface(surfaceNative, width, height), nil
 }
 
 // Finish finishes the surface. Further drawing operations will fail.
 func (s *Surface) Finish() {
+	defer runtime.KeepAlive(s)
 	C.cairo_surface_finish(s.surface)
 }
 
-// Destroy destroys the surface when no more references exist to it, freeing memory.
+// Reference returns a new Surface owning another reference to this surface.
+func (s *Surface) Reference() *Surface {
+	defer runtime.KeepAlive(s)
+	return newSurface(C.cairo_surface_reference(s.surface), s.pageWidth, s.pageHeight)
+}
+
+// Destroy releases the reference owned by this surface, freeing it when no other references exist.
+// It is safe to call more than once.
 func (s *Surface) Destroy() {
+	if !s.owned {
+		return
+	}
+	s.owned = false
+	runtime.SetFinalizer(s, nil)
 	C.cairo_surface_destroy(s.surface)
+	liveSurfaces.Add(-1)
+	untrackObject(s)
 }
 
 // GetReferenceCount gets the number of objects that are holding a reference to this surface.
 func (s *Surface) GetReferenceCount() int {
+	defer runtime.KeepAlive(s)
 	return int(C.cairo_surface_get_reference_count(s.surface))
 }
 
 // GetStatus gets the status of the surface based on the last operation.
 func (s *Surface) GetStatus() Status {
+	defer runtime.KeepAlive(s)
 	return Status(C.cairo_surface_status(s.surface))
 }
 
+// Err returns the status of the surface as an error, or nil if it succeeded.
+// For a surface created for an io.Writer, such as by NewPDFSurfaceForWriter,
+// it returns the first error the writer returned, so it can be matched with errors.Is.
+// Call it after Finish to check that the whole output was written.
+func (s *Surface) Err() error {
+	if err := s.writerErr(); err != nil {
+		return err
+	}
+	if status := s.GetStatus(); status != StatusSuccess {
+		return status
+	}
+	return nil
+}
+
+// GetType gets the type of the surface.
+func (s *Surface) GetType() SurfaceType {
+	defer runtime.KeepAlive(s)
+	return SurfaceType(C.cairo_surface_get_type(s.surface))
+}
+
+// IsVector returns whether this is a PDF, PS, SVG or recording surface.
+func (s *Surface) IsVector() bool {
+	t := s.GetType()
+	return t == SurfaceTypePDF || t == SurfaceTypePS || t == SurfaceTypeSVG || t == SurfaceTypeRecording
+}
+
+// usesPageSize returns whether the size of this surface is stored in the struct rather than queried from cairo.
+func (s *Surface) usesPageSize() bool {
+	return s.IsVector() || s.GetType() == SurfaceTypeSubsurface
+}
+
 // GetContent gets the content type of the surface.
 func (s *Surface) GetContent() Content {
+	defer runtime.KeepAlive(s)
 	return Content(C.cairo_surface_get_content(s.surface))
 }
 
 // WriteToPNG saves the surface to an external png file
 func (s *Surface) WriteToPNG(filename string) error {
+	defer runtime.KeepAlive(s)
 	cs := C.CString(filename)
 	defer C.free(unsafe.Pointer(cs))
 	status := Status(C.cairo_surface_write_to_png(s.surface, cs))
 	if status != StatusSuccess {
-		return errors.New(status.String())
+		return status
 	}
 	return nil
 }
 
 // Flush finished any pending drawing operations on the surface.
 func (s *Surface) Flush() {
+	defer runtime.KeepAlive(s)
 	C.cairo_surface_flush(s.surface)
 }
 
 // MarkDirty tbd
 func (s *Surface) MarkDirty() {
+	defer runtime.KeepAlive(s)
 	C.cairo_surface_mark_dirty(s.surface)
 }
 
 // MarkDirtyRectangle tbd
 func (s *Surface) MarkDirtyRectangle(x, y, width, height int) {
+	defer runtime.KeepAlive(s)
 	C.cairo_surface_mark_dirty_rectangle(s.surface,
 		C.int(x), C.int(y), C.int(width), C.int(height))
 }
 
 // SetDeviceOffset tbd
 func (s *Surface) SetDeviceOffset(x, y float64) {
+	defer runtime.KeepAlive(s)
 	C.cairo_surface_set_device_offset(s.surface, C.double(x), C.double(y))
 }
 
 // GetDeviceOffset tbd
 func (s *Surface) GetDeviceOffset() (x, y float64) {
+	defer runtime.KeepAlive(s)
 	C.cairo_surface_get_device_offset(s.surface, (*C.double)(&x), (*C.double)(&y))
 	return x, y
 }
 
 // SetFallbackResolution tbd
 func (s *Surface) SetFallbackResolution(xPixelPerInch, yPixelPerInch float64) {
+	defer runtime.KeepAlive(s)
 	C.cairo_surface_set_fallback_resolution(s.surface,
 		C.double(xPixelPerInch), C.double(yPixelPerInch))
 }
 
 // GetFallbackResolution tbd
 func (s *Surface) GetFallbackResolution() (xPixelPerInch, yPixelPerInch float64) {
+	defer runtime.KeepAlive(s)
 	C.cairo_surface_get_fallback_resolution(s.surface,
 		(*C.double)(&xPixelPerInch), (*C.double)(&yPixelPerInch))
 	return xPixelPerInch, yPixelPerInch
@@ -123,12 +198,14 @@ func (s *Surface) GetFallbackResolution() (xPixelPerInch, yPixelPerInch float64)
 
 // HasShowTextGlyphs tbd
 func (s *Surface) HasShowTextGlyphs() bool {
+	defer runtime.KeepAlive(s)
 	return C.cairo_surface_has_show_text_glyphs(s.surface) != 0
 }
 
 // GetData returns a copy of the surfaces raw pixel data.
 // This method also calls Flush.
 func (s *Surface) GetData() ([]byte, error) {
+	defer runtime.KeepAlive(s)
 	s.Flush()
 	dataPtr := C.cairo_image_surface_get_data(s.surface)
 	if dataPtr == nil {
@@ -142,6 +219,7 @@ func (s *Surface) GetData() ([]byte, error) {
 // SetData sets the surfaces raw pixel data.
 // This method also calls Flush and MarkDirty.
 func (s *Surface) SetData(data []byte) error {
+	defer runtime.KeepAlive(s)
 	s.Flush()
 	dataPtr := unsafe.Pointer(C.cairo_image_surface_get_data(s.surface))
 	if dataPtr == nil {
@@ -159,26 +237,43 @@ func (s *Surface) SetData(data []byte) error {
 
 // GetFormat returns the format of the surface.
 func (s *Surface) GetFormat() Format {
+	defer runtime.KeepAlive(s)
 	return Format(C.cairo_image_surface_get_format(s.surface))
 }
 
 // GetWidth returns the width of the surface.
+// For vector surfaces this is the page width in points, and for sub-surfaces the width of the region.
 func (s *Surface) GetWidth() int {
+	defer runtime.KeepAlive(s)
+	if s.usesPageSize() {
+		return int(s.pageWidth)
+	}
 	return int(C.cairo_image_surface_get_width(s.surface))
 }
 
 // GetHeight returns the height of the surface.
+// For vector surfaces this is the page height in points, and for sub-surfaces the height of the region.
 func (s *Surface) GetHeight() int {
+	defer runtime.KeepAlive(s)
+	if s.usesPageSize() {
+		return int(s.pageHeight)
+	}
 	return int(C.cairo_image_surface_get_height(s.surface))
 }
 
 // GetWidthF returns the width of the surface as a float64.
 func (s *Surface) GetWidthF() float64 {
+	if s.usesPageSize() {
+		return s.pageWidth
+	}
 	return float64(s.GetWidth())
 }
 
 // GetHeightF returns the height of the surface as a float64.
 func (s *Surface) GetHeightF() float64 {
+	if s.usesPageSize() {
+		return s.pageHeight
+	}
 	return float64(s.GetHeight())
 }
 
@@ -194,11 +289,13 @@ func (s *Surface) SizeF() (float64, float64) {
 
 // GetStride returns the stride of the surface.
 func (s *Surface) GetStride() int {
+	defer runtime.KeepAlive(s)
 	return int(C.cairo_image_surface_get_stride(s.surface))
 }
 
-// GetPixel returns the b, g, r, a value at a given x, y location.
+// GetPixel returns the r, g, b, a value at a given x, y location, from data returned by GetData.
+// The data is read according to the surface's format. Formats without alpha return 255 for alpha,
+// and alpha only formats return 0 for r, g and b.
 func (s *Surface) GetPixel(data []byte, x int, y int) (byte, byte, byte, byte) {
-	index := (y*s.GetWidth() + x) * 4
-	return data[index+2], data[index+1], data[index], data[index+3]
+	return readPixel(data, s.GetFormat(), s.GetStride(), x, y)
 }
@@ -0,0 +1,98 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+import (
+	"errors"
+	"image"
+	"image/draw"
+)
+
+// ToImage returns a copy of the surface's pixels as an *image.NRGBA.
+// Cairo stores premultiplied alpha, so color values are un-premultiplied in the process.
+// This method also calls Flush.
+func (s *Surface) ToImage() (*image.NRGBA, error) {
+	format := s.GetFormat()
+	if format != FormatARGB32 && format != FormatRGB24 {
+		return nil, errors.New("cairo.Surface.ToImage(): unsupported surface format")
+	}
+	data, err := s.GetData()
+	if err != nil {
+		return nil, err
+	}
+	w, h := s.Size()
+	stride := s.GetStride()
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			// note channel order: bgra
+			src := y*stride + x*4
+			dst := img.PixOffset(x, y)
+			b, g, r, a := data[src], data[src+1], data[src+2], data[src+3]
+			if format == FormatRGB24 {
+				a = 255
+			}
+			img.Pix[dst] = unpremultiply(r, a)
+			img.Pix[dst+1] = unpremultiply(g, a)
+			img.Pix[dst+2] = unpremultiply(b, a)
+			img.Pix[dst+3] = a
+		}
+	}
+	return img, nil
+}
+
+// unpremultiply converts a premultiplied color channel to a straight one.
+func unpremultiply(value, alpha byte) byte {
+	if alpha == 0 {
+		return 0
+	}
+	if alpha == 255 {
+		return value
+	}
+	v := (uint32(value)*255 + uint32(alpha)/2) / uint32(alpha)
+	if v > 255 {
+		v = 255
+	}
+	return byte(v)
+}
+
+// NewSurfaceFromImage creates a new ARGB32 surface containing a copy of the given image.
+// An empty image gives an empty surface.
+func NewSurfaceFromImage(img image.Image) (*Surface, error) {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+
+	// image.RGBA is premultiplied, same as cairo.
+	rgba, ok := img.(*image.RGBA)
+	if !ok || rgba.Rect.Min != (image.Point{}) {
+		rgba = image.NewRGBA(image.Rect(0, 0, w, h))
+		draw.Draw(rgba, rgba.Bounds(), img, bounds.Min, draw.Src)
+	}
+
+	surface := NewSurface(w, h)
+	status := surface.GetStatus()
+	if status != StatusSuccess {
+		surface.Destroy()
+		return nil, status
+	}
+	if w == 0 || h == 0 {
+		return surface, nil
+	}
+	stride := surface.GetStride()
+	data := make([]byte, stride*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			src := rgba.PixOffset(x, y)
+			dst := y*stride + x*4
+			data[dst] = rgba.Pix[src+2]
+			data[dst+1] = rgba.Pix[src+1]
+			data[dst+2] = rgba.Pix[src]
+			data[dst+3] = rgba.Pix[src+3]
+		}
+	}
+	err := surface.SetData(data)
+	if err != nil {
+		surface.Destroy()
+		return nil, err
+	}
+	return surface, nil
+}
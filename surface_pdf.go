@@ -0,0 +1,136 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+// #include <cairo/cairo-pdf.h>
+// #include <stdlib.h>
+// extern cairo_status_t blcairoWriteFunc(void *closure, unsigned char *data, unsigned int length);
+// extern void blcairoDeleteHandle(void *data);
+import "C"
+
+import (
+	"io"
+	"runtime"
+	"unsafe"
+)
+
+// pdfWriterKey is the user data key under which a pdf surface created for a writer keeps its writer.
+const pdfWriterKey = "blcairo.pdfwriter"
+
+// NewPDFSurface creates a new PDF surface that writes to the given file.
+// width and height are the size of each page in points (1/72 inch).
+// Call ShowPage on a context to start a new page, and Finish or Destroy on the surface to complete the file.
+func NewPDFSurface(filename string, width, height float64) (*Surface, error) {
+	cstr := C.CString(filename)
+	defer C.free(unsafe.Pointer(cstr))
+
+	surfaceNative := C.cairo_pdf_surface_create(cstr, C.double(width), C.double(height))
+	return newVectorSurface(surfaceNative, width, height)
+}
+
+// NewPDFSurfaceForWriter creates a new PDF surface that writes to the given writer, as NewPDFSurface does to a file.
+// The PDF is written as drawing goes on, and completed by Finish or Destroy.
+// If the writer returns an error, the surface's status becomes StatusWriteError and Err returns the writer's error.
+func NewPDFSurfaceForWriter(w io.Writer, width, height float64) (*Surface, error) {
+	data := newHandleData(&streamWriter{writer: w})
+	surfaceNative := C.cairo_pdf_surface_create_for_stream(C.cairo_write_func_t(C.blcairoWriteFunc), data,
+		C.double(width), C.double(height))
+
+	// the surface owns the writer handle and releases it when it is destroyed.
+	status := Status(C.cairo_surface_set_user_data(surfaceNative, userDataKey(pdfWriterKey), data,
+		C.cairo_destroy_func_t(C.blcairoDeleteHandle)))
+	if status != StatusSuccess {
+		blcairoDeleteHandle(data)
+		C.cairo_surface_destroy(surfaceNative)
+		return nil, status
+	}
+	return newVectorSurface(surfaceNative, width, height)
+}
+
+// writerErr returns the first error returned by the writer of a surface created for an io.Writer, or nil.
+func (s *Surface) writerErr() error {
+	defer runtime.KeepAlive(s)
+	sw, ok := handleDataValue(C.cairo_surface_get_user_data(s.surface, userDataKey(pdfWriterKey))).(*streamWriter)
+	if !ok {
+		return nil
+	}
+	return sw.err
+}
+
+// RestrictToPDFVersion restricts the generated PDF file to the given version.
+// This should be called before any drawing takes place on the surface.
+func (s *Surface) RestrictToPDFVersion(version PDFVersion) {
+	defer runtime.KeepAlive(s)
+	C.cairo_pdf_surface_restrict_to_version(s.surface, C.cairo_pdf_version_t(version))
+}
+
+func (v PDFVersion) String() string {
+	return C.GoString(C.cairo_pdf_version_to_string(C.cairo_pdf_version_t(v)))
+}
+
+// SetPDFSize changes the size of the PDF surface's pages, in points, starting with the current page.
+// Call it before any drawing on the page, either right after creating the surface or after ShowPage.
+// GetWidth and GetHeight report the new size. See also Context.SetPDFPageSize.
+func (s *Surface) SetPDFSize(width, height float64) {
+	defer runtime.KeepAlive(s)
+	C.cairo_pdf_surface_set_size(s.surface, C.double(width), C.double(height))
+	s.pageWidth = width
+	s.pageHeight = height
+}
+
+// SetPDFPageSize changes the size of the PDF surface's pages, as Surface.SetPDFSize does,
+// and updates the context's Width and Height to match.
+func (c *Context) SetPDFPageSize(width, height float64) {
+	c.Surface.SetPDFSize(width, height)
+	c.Width = width
+	c.Height = height
+}
+
+// SetPDFMetadata sets an item of the document's metadata, such as its title or author.
+func (s *Surface) SetPDFMetadata(metadata PDFMetadata, value string) {
+	defer runtime.KeepAlive(s)
+	cstr := C.CString(value)
+	defer C.free(unsafe.Pointer(cstr))
+	C.cairo_pdf_surface_set_metadata(s.surface, C.cairo_pdf_metadata_t(metadata), cstr)
+}
+
+// SetPDFPageLabel sets the label shown for the current page by PDF viewers, such as "iv" or "Cover".
+func (s *Surface) SetPDFPageLabel(label string) {
+	defer runtime.KeepAlive(s)
+	cstr := C.CString(label)
+	defer C.free(unsafe.Pointer(cstr))
+	C.cairo_pdf_surface_set_page_label(s.surface, cstr)
+}
+
+// SetPDFThumbnailSize sets the size of the page thumbnails embedded in the PDF. A size of 0, 0 turns them off.
+func (s *Surface) SetPDFThumbnailSize(width, height int) {
+	defer runtime.KeepAlive(s)
+	C.cairo_pdf_surface_set_thumbnail_size(s.surface, C.int(width), C.int(height))
+}
+
+////////////////////
+// Outline
+////////////////////
+
+// AddPDFOutline adds an item to the document outline, shown as bookmarks by PDF viewers, and returns its id.
+// parentID is PDFOutlineRoot or the id of another item. linkAttributes say where the item goes,
+// as for a TagLink tag, for example "page=3" or "dest='chapter2'".
+func (s *Surface) AddPDFOutline(parentID int, name, linkAttributes string, flags PDFOutlineFlags) int {
+	defer runtime.KeepAlive(s)
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	cattributes := C.CString(linkAttributes)
+	defer C.free(unsafe.Pointer(cattributes))
+	return int(C.cairo_pdf_surface_add_outline(s.surface, C.int(parentID), cname, cattributes,
+		C.cairo_pdf_outline_flags_t(flags)))
+}
+
+// AddPDFOutlineToPage adds an outline item going to the given page, counting from 1, and returns its id.
+func (s *Surface) AddPDFOutlineToPage(parentID int, name string, page int, flags PDFOutlineFlags) int {
+	return s.AddPDFOutline(parentID, name, pageAttribute(page), flags)
+}
+
+// AddPDFOutlineToDest adds an outline item going to a named destination, and returns its id.
+// See Context.AddDestination.
+func (s *Surface) AddPDFOutlineToDest(parentID int, name, dest string, flags PDFOutlineFlags) int {
+	return s.AddPDFOutline(parentID, name, "dest="+tagString(dest), flags)
+}
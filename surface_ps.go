@@ -0,0 +1,46 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+// #include <cairo/cairo-ps.h>
+// #include <stdlib.h>
+import "C"
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+// NewPSSurface creates a new PostScript surface that writes to the given file.
+// width and height are the size of each page in points (1/72 inch).
+// Call ShowPage on a context to start a new page, and Finish or Destroy on the surface to complete the file.
+func NewPSSurface(filename string, width, height float64) (*Surface, error) {
+	cstr := C.CString(filename)
+	defer C.free(unsafe.Pointer(cstr))
+
+	surfaceNative := C.cairo_ps_surface_create(cstr, C.double(width), C.double(height))
+	return newVectorSurface(surfaceNative, width, height)
+}
+
+// RestrictToPSLevel restricts the generated PostScript file to the given language level.
+// This should be called before any drawing takes place on the surface.
+func (s *Surface) RestrictToPSLevel(level PSLevel) {
+	defer runtime.KeepAlive(s)
+	C.cairo_ps_surface_restrict_to_level(s.surface, C.cairo_ps_level_t(level))
+}
+
+// SetEPS sets whether the PostScript surface will output Encapsulated PostScript.
+// This should be called before any drawing takes place on the surface.
+func (s *Surface) SetEPS(eps bool) {
+	defer runtime.KeepAlive(s)
+	var value C.cairo_bool_t
+	if eps {
+		value = 1
+	}
+	C.cairo_ps_surface_set_eps(s.surface, value)
+}
+
+// GetEPS returns whether the PostScript surface will output Encapsulated PostScript.
+func (s *Surface) GetEPS() bool {
+	defer runtime.KeepAlive(s)
+	return C.cairo_ps_surface_get_eps(s.surface) != 0
+}
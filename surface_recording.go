@@ -0,0 +1,78 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+// #include <cairo/cairo.h>
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+)
+
+// NewRecordingSurface creates a surface that records drawing operations so they can be replayed later,
+// at any scale and onto any other surface. Drawing is clipped to the rectangle x, y, w, h.
+func NewRecordingSurface(content Content, x, y, w, h float64) (*Surface, error) {
+	extents := C.cairo_rectangle_t{x: C.double(x), y: C.double(y), width: C.double(w), height: C.double(h)}
+	return newVectorSurface(C.cairo_recording_surface_create(C.cairo_content_t(content), &extents), w, h)
+}
+
+// NewUnboundedRecordingSurface creates a recording surface that records drawing anywhere.
+// Its width and height are 0. Use GetInkExtents to find what was drawn.
+func NewUnboundedRecordingSurface(content Content) (*Surface, error) {
+	return newVectorSurface(C.cairo_recording_surface_create(C.cairo_content_t(content), nil), 0, 0)
+}
+
+// GetInkExtents returns the bounding box of everything drawn on a recording surface.
+func (s *Surface) GetInkExtents() Rectangle {
+	defer runtime.KeepAlive(s)
+	var x, y, w, h C.double
+	C.cairo_recording_surface_ink_extents(s.surface, &x, &y, &w, &h)
+	return Rectangle{float64(x), float64(y), float64(w), float64(h)}
+}
+
+// GetRecordingExtents returns the rectangle a recording surface was created with.
+// It returns false if the recording surface is unbounded.
+func (s *Surface) GetRecordingExtents() (Rectangle, bool) {
+	defer runtime.KeepAlive(s)
+	var extents C.cairo_rectangle_t
+	if C.cairo_recording_surface_get_extents(s.surface, &extents) == 0 {
+		return Rectangle{}, false
+	}
+	return Rectangle{float64(extents.x), float64(extents.y), float64(extents.width), float64(extents.height)}, true
+}
+
+// Replay draws the contents of a recording surface with its origin at x, y, scaled by scale.
+// Vector drawing is replayed as vector drawing, so it stays sharp at any scale and in pdf and svg output.
+func (c *Context) Replay(recording *Surface, x, y, scale float64) {
+	c.Save()
+	c.Translate(x, y)
+	c.Scale(scale, scale)
+	c.SetSourceSurface(recording, 0, 0)
+	c.Paint()
+	c.Restore()
+}
+
+// RenderInkToImage renders the ink of a recording surface onto a new image surface, cropped to the ink extents
+// plus a margin on each side. The margin is in image pixels. This is useful for trimming artwork before export.
+func (s *Surface) RenderInkToImage(scale, margin float64) (*Surface, error) {
+	if s.GetType() != SurfaceTypeRecording {
+		return nil, errors.New("surface is not a recording surface")
+	}
+	ink := s.GetInkExtents()
+	if ink.Width <= 0 || ink.Height <= 0 {
+		return nil, errors.New("recording surface has no ink")
+	}
+	w := int(math.Ceil(ink.Width*scale + margin*2))
+	h := int(math.Ceil(ink.Height*scale + margin*2))
+	surface := NewSurface(w, h)
+	if status := surface.GetStatus(); status != StatusSuccess {
+		surface.Destroy()
+		return nil, fmt.Errorf("unable to create %d x %d image: %w", w, h, status)
+	}
+	context := NewContext(surface)
+	defer context.Destroy()
+	context.Replay(s, margin-ink.X*scale, margin-ink.Y*scale, scale)
+	return surface, nil
+}
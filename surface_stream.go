@@ -0,0 +1,95 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+// #include <cairo/cairo.h>
+// extern cairo_status_t blcairoWriteFunc(void *closure, unsigned char *data, unsigned int length);
+// extern cairo_status_t blcairoReadFunc(void *closure, unsigned char *data, unsigned int length);
+import "C"
+
+import (
+	"io"
+	"runtime"
+	"runtime/cgo"
+	"unsafe"
+)
+
+// streamWriter holds an io.Writer and the first error it returned.
+type streamWriter struct {
+	writer io.Writer
+	err    error
+}
+
+// streamReader holds an io.Reader and the first error it returned.
+type streamReader struct {
+	reader io.Reader
+	err    error
+}
+
+//export blcairoWriteFunc
+func blcairoWriteFunc(closure unsafe.Pointer, data *C.uchar, length C.uint) C.cairo_status_t {
+	sw := (*(*cgo.Handle)(closure)).Value().(*streamWriter)
+	if sw.err != nil {
+		return C.cairo_status_t(StatusWriteError)
+	}
+	_, err := sw.writer.Write(unsafe.Slice((*byte)(unsafe.Pointer(data)), int(length)))
+	if err != nil {
+		sw.err = err
+		return C.cairo_status_t(StatusWriteError)
+	}
+	return C.cairo_status_t(StatusSuccess)
+}
+
+//export blcairoReadFunc
+func blcairoReadFunc(closure unsafe.Pointer, data *C.uchar, length C.uint) C.cairo_status_t {
+	sr := (*(*cgo.Handle)(closure)).Value().(*streamReader)
+	if sr.err != nil {
+		return C.cairo_status_t(StatusReadError)
+	}
+	_, err := io.ReadFull(sr.reader, unsafe.Slice((*byte)(unsafe.Pointer(data)), int(length)))
+	if err != nil {
+		sr.err = err
+		return C.cairo_status_t(StatusReadError)
+	}
+	return C.cairo_status_t(StatusSuccess)
+}
+
+// WriteToPNGStream writes the surface as png data to the given writer.
+// Any error returned by the writer is returned from this method.
+func (s *Surface) WriteToPNGStream(w io.Writer) error {
+	defer runtime.KeepAlive(s)
+	sw := &streamWriter{writer: w}
+	handle := cgo.NewHandle(sw)
+	defer handle.Delete()
+
+	status := Status(C.cairo_surface_write_to_png_stream(s.surface,
+		C.cairo_write_func_t(C.blcairoWriteFunc), unsafe.Pointer(&handle)))
+	if sw.err != nil {
+		return sw.err
+	}
+	if status != StatusSuccess {
+		return status
+	}
+	return nil
+}
+
+// NewSurfaceFromPNGReader creates a new Surface struct from png data read from the given reader.
+// Any error returned by the reader is returned from this function.
+func NewSurfaceFromPNGReader(r io.Reader) (*Surface, error) {
+	sr := &streamReader{reader: r}
+	handle := cgo.NewHandle(sr)
+	defer handle.Delete()
+
+	surfaceNative := C.cairo_image_surface_create_from_png_stream(
+		C.cairo_read_func_t(C.blcairoReadFunc), unsafe.Pointer(&handle))
+	if sr.err != nil {
+		C.cairo_surface_destroy(surfaceNative)
+		return nil, sr.err
+	}
+	status := Status(C.cairo_surface_status(surfaceNative))
+	if status != StatusSuccess {
+		C.cairo_surface_destroy(surfaceNative)
+		return nil, status
+	}
+
+	return newSurface(surfaceNative, 0, 0), nil
+}
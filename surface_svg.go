@@ -0,0 +1,41 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+// #include <cairo/cairo-svg.h>
+// #include <stdlib.h>
+import "C"
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+// NewSVGSurface creates a new SVG surface that writes to the given file.
+// width and height are the size of the document in points (1/72 inch).
+// Call Finish or Destroy on the surface to complete the file.
+func NewSVGSurface(filename string, width, height float64) (*Surface, error) {
+	cstr := C.CString(filename)
+	defer C.free(unsafe.Pointer(cstr))
+
+	surfaceNative := C.cairo_svg_surface_create(cstr, C.double(width), C.double(height))
+	return newVectorSurface(surfaceNative, width, height)
+}
+
+// RestrictToSVGVersion restricts the generated SVG file to the given version.
+// This should be called before any drawing takes place on the surface.
+func (s *Surface) RestrictToSVGVersion(version SVGVersion) {
+	defer runtime.KeepAlive(s)
+	C.cairo_svg_surface_restrict_to_version(s.surface, C.cairo_svg_version_t(version))
+}
+
+// SetSVGDocumentUnit sets the unit used for the width and height attributes of the SVG root element.
+func (s *Surface) SetSVGDocumentUnit(unit SVGUnit) {
+	defer runtime.KeepAlive(s)
+	C.cairo_svg_surface_set_document_unit(s.surface, C.cairo_svg_unit_t(unit))
+}
+
+// GetSVGDocumentUnit gets the unit used for the width and height attributes of the SVG root element.
+func (s *Surface) GetSVGDocumentUnit() SVGUnit {
+	defer runtime.KeepAlive(s)
+	return SVGUnit(C.cairo_svg_surface_get_document_unit(s.surface))
+}
@@ -2,8 +2,14 @@
 package cairo
 
 import (
+	"bytes"
 	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -171,3 +177,414 @@ func TestSetData(t *testing.T) {
 		t.Errorf("Expected data[99] to be %d, got %d\n", 199, data[99])
 	}
 }
+
+func TestPDFSurface(t *testing.T) {
+	path := "testdata/temp.pdf"
+	surface, err := NewPDFSurface(path, 595, 842)
+	if err != nil {
+		t.Errorf("Unable to create pdf surface. Error: %s\n", err)
+	}
+
+	surfaceType := surface.GetType()
+	if surfaceType != SurfaceTypePDF {
+		t.Errorf("Expected surface type %v, got %v\n", SurfaceTypePDF, surfaceType)
+	}
+
+	context := NewContext(surface)
+	if context.Width != 595 || context.Height != 842 {
+		t.Errorf("Expected context size 595x842, got %fx%f\n", context.Width, context.Height)
+	}
+	context.Rectangle(100, 100, 100, 100)
+	context.Fill()
+	context.ShowPage()
+	context.Rectangle(200, 200, 100, 100)
+	context.Fill()
+	context.ShowPage()
+	surface.Finish()
+
+	status := surface.GetStatus()
+	if status != StatusSuccess {
+		t.Errorf("Expected status %q, got %q\n", StatusSuccess, status)
+	}
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		t.Errorf("File was not saved.")
+	}
+	os.Remove(path)
+}
+
+func TestPDFFeatures(t *testing.T) {
+	var buffer bytes.Buffer
+	surface, err := NewPDFSurfaceForWriter(&buffer, 400, 300)
+	if err != nil {
+		t.Errorf("Unable to create pdf surface. Error: %s\n", err)
+		return
+	}
+	surface.SetPDFMetadata(PDFMetadataTitle, "Quarterly Report")
+	surface.SetPDFMetadata(PDFMetadataAuthor, "bit101")
+
+	context := NewContext(surface)
+	surface.SetPDFPageLabel("Cover")
+	context.BeginLinkURI("https://example.com/it's")
+	context.FillRectangle(10, 10, 100, 20)
+	context.EndLink()
+	context.BeginLinkDest("second")
+	context.FillRectangle(10, 50, 100, 20)
+	context.EndLink()
+	context.ShowPage()
+
+	context.SetPDFPageSize(200, 600)
+	if surface.GetWidth() != 200 || context.Height != 600 {
+		t.Errorf("Expected page size 200x600, got %dx%f\n", surface.GetWidth(), context.Height)
+	}
+	context.AddDestination("second", 0, 100)
+	context.FillRectangle(10, 100, 100, 20)
+	chapter := surface.AddPDFOutlineToPage(PDFOutlineRoot, "Cover", 1, PDFOutlineFlagOpen)
+	surface.AddPDFOutlineToDest(chapter, "Second page", "second", PDFOutlineFlagBold)
+	context.ShowPage()
+	if err := context.Err(); err != nil {
+		t.Errorf("Expected no context error, got %s\n", err)
+	}
+	context.Destroy()
+	surface.Finish()
+
+	if err := surface.Err(); err != nil {
+		t.Errorf("Expected no surface error, got %s\n", err)
+	}
+	pdf := buffer.String()
+	for _, expected := range []string{"%PDF", "Quarterly Report", "/URI", "/Outlines", "/MediaBox [ 0 0 200 600 ]"} {
+		if !strings.Contains(pdf, expected) {
+			t.Errorf("Expected pdf output to contain %q\n", expected)
+		}
+	}
+	surface.Destroy()
+}
+
+func TestPDFSurfaceWriterError(t *testing.T) {
+	surface, err := NewPDFSurfaceForWriter(failingWriter{}, 400, 300)
+	if err != nil {
+		t.Errorf("Unable to create pdf surface. Error: %s\n", err)
+		return
+	}
+	defer surface.Destroy()
+	context := NewContext(surface)
+	context.FillRectangle(10, 10, 100, 20)
+	context.ShowPage()
+	context.Destroy()
+	surface.Finish()
+
+	err = surface.Err()
+	if !errors.Is(err, errFailingWriter) {
+		t.Errorf("Expected error %v, got %v\n", errFailingWriter, err)
+	}
+	if surface.GetStatus() != StatusWriteError {
+		t.Errorf("Expected status %q, got %q\n", StatusWriteError, surface.GetStatus())
+	}
+}
+
+func TestSVGSurface(t *testing.T) {
+	path := "testdata/temp.svg"
+	surface, err := NewSVGSurface(path, 400, 300)
+	if err != nil {
+		t.Errorf("Unable to create svg surface. Error: %s\n", err)
+	}
+	surface.SetSVGDocumentUnit(SVGUnitPx)
+	unit := surface.GetSVGDocumentUnit()
+	if unit != SVGUnitPx {
+		t.Errorf("Expected document unit %v, got %v\n", SVGUnitPx, unit)
+	}
+
+	width, height := surface.Size()
+	if width != 400 || height != 300 {
+		t.Errorf("Expected size 400x300, got %dx%d\n", width, height)
+	}
+	surface.Finish()
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		t.Errorf("File was not saved.")
+	}
+	os.Remove(path)
+}
+
+type failingWriter struct{}
+
+var errFailingWriter = errors.New("failing writer")
+
+func (w failingWriter) Write(p []byte) (int, error) {
+	return 0, errFailingWriter
+}
+
+func TestPNGStream(t *testing.T) {
+	surface := NewSurface(20, 10)
+	context := NewContext(surface)
+	context.SetSourceRGB(1, 0, 0)
+	context.Paint()
+
+	var buffer bytes.Buffer
+	err := surface.WriteToPNGStream(&buffer)
+	if err != nil {
+		t.Errorf("Unable to write png stream. Error: %s\n", err)
+	}
+
+	streamSurface, err := NewSurfaceFromPNGReader(&buffer)
+	if err != nil {
+		t.Fatalf("Unable to read png stream. Error: %s\n", err)
+	}
+	width, height := streamSurface.Size()
+	if width != 20 || height != 10 {
+		t.Errorf("Expected size 20x10, got %dx%d\n", width, height)
+	}
+	data := getData(streamSurface, t)
+	if data[2] != 255 {
+		t.Errorf("Expected data[2] to be %d, got %d\n", 255, data[2])
+	}
+
+	err = surface.WriteToPNGStream(failingWriter{})
+	if !errors.Is(err, errFailingWriter) {
+		t.Errorf("Expected error %v, got %v\n", errFailingWriter, err)
+	}
+
+	_, err = NewSurfaceFromPNGReader(bytes.NewReader([]byte("not a png")))
+	if err == nil {
+		t.Errorf("Expected error reading invalid png data\n")
+	}
+}
+
+func TestToImage(t *testing.T) {
+	surface := NewSurface(10, 10)
+	context := NewContext(surface)
+	context.SetSourceRGBA(1, 0, 0, 0.5)
+	context.Paint()
+
+	img, err := surface.ToImage()
+	if err != nil {
+		t.Fatalf("Unable to convert surface to image. Error: %s\n", err)
+	}
+	c := img.NRGBAAt(5, 5)
+	if c.R != 255 || c.G != 0 || c.B != 0 {
+		t.Errorf("Expected un-premultiplied red, got %v\n", c)
+	}
+	if c.A < 127 || c.A > 128 {
+		t.Errorf("Expected alpha of about 128, got %d\n", c.A)
+	}
+
+	imgSurface, err := NewSurfaceFromImage(img)
+	if err != nil {
+		t.Fatalf("Unable to create surface from image. Error: %s\n", err)
+	}
+	data := getData(imgSurface, t)
+	if data[2] != data[3] {
+		t.Errorf("Expected premultiplied red %d to equal alpha %d\n", data[2], data[3])
+	}
+
+	empty, err := NewSurfaceFromImage(image.NewRGBA(image.Rect(0, 0, 0, 0)))
+	if err != nil {
+		t.Fatalf("Unable to create surface from empty image. Error: %s\n", err)
+	}
+	defer empty.Destroy()
+	if empty.GetWidth() != 0 || empty.GetHeight() != 0 {
+		t.Errorf("Expected empty surface, got %d x %d\n", empty.GetWidth(), empty.GetHeight())
+	}
+}
+
+func TestImageDataAsImage(t *testing.T) {
+	var _ draw.Image = &ImageData{}
+
+	imageData := NewImageData(10, 10)
+	draw.Draw(&imageData, image.Rect(0, 0, 5, 5), image.NewUniform(color.RGBA{0, 0, 255, 255}), image.Point{}, draw.Src)
+
+	c := imageData.At(2, 2).(color.RGBA)
+	if c != (color.RGBA{0, 0, 255, 255}) {
+		t.Errorf("Expected blue, got %v\n", c)
+	}
+	r, g, b, a := imageData.GetPixelInt(2, 2)
+	if r != 0 || g != 0 || b != 255 || a != 255 {
+		t.Errorf("Expected 0, 0, 255, 255, got %d, %d, %d, %d\n", r, g, b, a)
+	}
+	if imageData.Bounds() != image.Rect(0, 0, 10, 10) {
+		t.Errorf("Unexpected bounds %v\n", imageData.Bounds())
+	}
+}
+
+func TestRecordingSurface(t *testing.T) {
+	recording, err := NewUnboundedRecordingSurface(ContentColorAlpha)
+	if err != nil {
+		t.Errorf("Expected no error creating recording surface, got %s\n", err)
+		return
+	}
+	defer recording.Destroy()
+	if !recording.IsVector() {
+		t.Errorf("Expected recording surface to be a vector surface\n")
+	}
+	if _, ok := recording.GetRecordingExtents(); ok {
+		t.Errorf("Expected unbounded recording surface to have no extents\n")
+	}
+	context := NewContext(recording)
+	context.SetSourceRGB(1, 0, 0)
+	context.FillRectangle(-20, 30, 40, 10)
+	context.Destroy()
+
+	ink := recording.GetInkExtents()
+	if ink != (Rectangle{-20, 30, 40, 10}) {
+		t.Errorf("Expected ink extents {-20 30 40 10}, got %v\n", ink)
+	}
+
+	cropped, err := recording.RenderInkToImage(2, 5)
+	if err != nil {
+		t.Errorf("Expected no error rendering ink, got %s\n", err)
+		return
+	}
+	defer cropped.Destroy()
+	if cropped.GetWidth() != 90 || cropped.GetHeight() != 30 {
+		t.Errorf("Expected 90 x 30 image, got %d x %d\n", cropped.GetWidth(), cropped.GetHeight())
+	}
+	data := getData(cropped, t)
+	if r, _, _, a := cropped.GetPixel(data, 45, 15); r != 255 || a != 255 {
+		t.Errorf("Expected red in the middle of the image, got r %d, a %d\n", r, a)
+	}
+	if _, _, _, a := cropped.GetPixel(data, 2, 2); a != 0 {
+		t.Errorf("Expected transparent margin, got alpha %d\n", a)
+	}
+
+	bounded, _ := NewRecordingSurface(ContentColorAlpha, 0, 0, 100, 50)
+	defer bounded.Destroy()
+	if bounded.GetWidth() != 100 || bounded.GetHeight() != 50 {
+		t.Errorf("Expected bounded recording size 100 x 50, got %d x %d\n", bounded.GetWidth(), bounded.GetHeight())
+	}
+}
+
+func TestSurfaceUtilities(t *testing.T) {
+	surface := NewSurface(100, 100)
+	context := NewContext(surface)
+	context.SetSourceRGB(1, 0, 0)
+	context.FillRectangle(50, 50, 50, 50)
+
+	sub, err := surface.SubSurface(40, 40, 20, 20)
+	if err != nil {
+		t.Errorf("Expected no error creating sub-surface, got %s\n", err)
+		return
+	}
+	if sub.GetWidth() != 20 || sub.GetHeight() != 20 {
+		t.Errorf("Expected sub-surface size 20 x 20, got %d x %d\n", sub.GetWidth(), sub.GetHeight())
+	}
+	subContext := NewContext(sub)
+	subContext.SetSourceRGB(0, 0, 1)
+	subContext.FillRectangle(0, 0, 5, 5)
+	subContext.Destroy()
+	sub.Destroy()
+	data := getData(surface, t)
+	if _, _, b, _ := surface.GetPixel(data, 42, 42); b != 255 {
+		t.Errorf("Expected sub-surface drawing at 42, 42, got blue %d\n", b)
+	}
+
+	cropped, err := surface.Crop(40, 40, 20, 20)
+	if err != nil {
+		t.Errorf("Expected no error cropping, got %s\n", err)
+		return
+	}
+	defer cropped.Destroy()
+	data = getData(cropped, t)
+	if r, _, _, _ := cropped.GetPixel(data, 15, 15); r != 255 {
+		t.Errorf("Expected red at 15, 15 of cropped surface, got red %d\n", r)
+	}
+	if _, _, b, _ := cropped.GetPixel(data, 2, 2); b != 255 {
+		t.Errorf("Expected blue at 2, 2 of cropped surface, got blue %d\n", b)
+	}
+
+	resized, err := surface.Resize(10, 10, FilterNearest)
+	if err != nil {
+		t.Errorf("Expected no error resizing, got %s\n", err)
+		return
+	}
+	defer resized.Destroy()
+	if resized.GetWidth() != 10 || resized.GetHeight() != 10 {
+		t.Errorf("Expected resized size 10 x 10, got %d x %d\n", resized.GetWidth(), resized.GetHeight())
+	}
+	data = getData(resized, t)
+	if r, _, _, a := resized.GetPixel(data, 8, 8); r != 255 || a != 255 {
+		t.Errorf("Expected red at 8, 8 of resized surface, got red %d, alpha %d\n", r, a)
+	}
+
+	clone, err := surface.Clone()
+	if err != nil {
+		t.Errorf("Expected no error cloning, got %s\n", err)
+		return
+	}
+	defer clone.Destroy()
+	original := getData(surface, t)
+	copied := getData(clone, t)
+	if !bytes.Equal(original, copied) {
+		t.Errorf("Expected clone to have the same pixel data\n")
+	}
+}
+
+func TestSurfaceFormats(t *testing.T) {
+	mask := NewSurfaceWithFormat(FormatA8, 10, 10)
+	defer mask.Destroy()
+	if mask.GetFormat() != FormatA8 {
+		t.Errorf("Expected format %d, got %d\n", FormatA8, mask.GetFormat())
+	}
+	maskContext := NewContext(mask)
+	maskContext.SetSourceRGBA(0, 0, 0, 1)
+	maskContext.FillRectangle(0, 0, 5, 10)
+	maskContext.Destroy()
+	data := getData(mask, t)
+	if len(data) != mask.GetStride()*10 {
+		t.Errorf("Expected %d bytes of A8 data, got %d\n", mask.GetStride()*10, len(data))
+	}
+	if _, _, _, a := mask.GetPixel(data, 2, 5); a != 255 {
+		t.Errorf("Expected A8 alpha 255 at 2, 5, got %d\n", a)
+	}
+	if _, _, _, a := mask.GetPixel(data, 7, 5); a != 0 {
+		t.Errorf("Expected A8 alpha 0 at 7, 5, got %d\n", a)
+	}
+
+	surface := NewSurface(10, 10)
+	context := NewContext(surface)
+	context.SetSourceRGB(1, 0, 0)
+	context.MaskSurface(mask, 0, 0)
+	data = getData(surface, t)
+	if r, _, _, a := surface.GetPixel(data, 2, 5); r != 255 || a != 255 {
+		t.Errorf("Expected masked red at 2, 5, got r %d, a %d\n", r, a)
+	}
+	if _, _, _, a := surface.GetPixel(data, 7, 5); a != 0 {
+		t.Errorf("Expected nothing outside the mask, got alpha %d\n", a)
+	}
+
+	rgb := NewSurfaceWithFormat(FormatRGB24, 10, 10)
+	defer rgb.Destroy()
+	data = getData(rgb, t)
+	if _, _, _, a := rgb.GetPixel(data, 0, 0); a != 255 {
+		t.Errorf("Expected RGB24 pixels to be opaque, got alpha %d\n", a)
+	}
+}
+
+func TestImageDataFormats(t *testing.T) {
+	for _, format := range []Format{FormatARGB32, FormatRGB24, FormatA8, FormatRGB30, FormatRGB16565} {
+		imageData := NewImageDataWithFormat(format, 7, 3)
+		imageData.SetPixelInt(6, 2, 255, 128, 0, 255)
+		r, g, b, a := imageData.GetPixelInt(6, 2)
+		if format == FormatA8 {
+			if a != 255 {
+				t.Errorf("Expected A8 alpha 255, got %d\n", a)
+			}
+			continue
+		}
+		if r != 255 || math.Abs(float64(g-128)) > 4 || b != 0 || a != 255 {
+			t.Errorf("Expected format %d pixel 255, 128, 0, 255, got %d, %d, %d, %d\n", format, r, g, b, a)
+		}
+
+		surface := NewSurfaceWithFormat(format, 7, 3)
+		if err := imageData.CopyToSurface(surface); err != nil {
+			t.Errorf("Expected no error copying format %d ImageData to surface, got %s\n", format, err)
+		}
+		data := getData(surface, t)
+		if sr, _, _, _ := surface.GetPixel(data, 6, 2); sr != 255 {
+			t.Errorf("Expected format %d surface red 255, got %d\n", format, sr)
+		}
+		surface.Destroy()
+	}
+
+	imageData := NewImageData(7, 3)
+	if err := imageData.CopyToSurface(NewSurfaceWithFormat(FormatA8, 7, 3)); err == nil {
+		t.Errorf("Expected an error copying ImageData to a surface of another format\n")
+	}
+}
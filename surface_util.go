@@ -0,0 +1,88 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+// #include <cairo/cairo.h>
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+)
+
+// SubSurface creates a view of the rectangle x, y, w, h of this surface.
+// Drawing on the sub-surface draws on this surface, with 0, 0 at x, y and clipped to the rectangle.
+// Using the sub-surface as a source reads only the pixels in the rectangle.
+func (s *Surface) SubSurface(x, y, w, h float64) (*Surface, error) {
+	defer runtime.KeepAlive(s)
+	if w <= 0 || h <= 0 {
+		return nil, fmt.Errorf("invalid sub-surface size %f x %f", w, h)
+	}
+	native := C.cairo_surface_create_for_rectangle(s.surface, C.double(x), C.double(y), C.double(w), C.double(h))
+	status := Status(C.cairo_surface_status(native))
+	if status != StatusSuccess {
+		return nil, status
+	}
+	return newSurface(native, w, h), nil
+}
+
+// newImageSurfaceLike creates an image surface of the given size with the same format as this surface,
+// or ARGB32 if this is not an image surface.
+func (s *Surface) newImageSurfaceLike(w, h int) (*Surface, error) {
+	if w <= 0 || h <= 0 {
+		return nil, fmt.Errorf("invalid surface size %d x %d", w, h)
+	}
+	format := FormatARGB32
+	if s.GetType() == SurfaceTypeImage {
+		format = s.GetFormat()
+	}
+	surface := newSurface(C.cairo_image_surface_create(C.cairo_format_t(format), C.int(w), C.int(h)), 0, 0)
+	if status := surface.GetStatus(); status != StatusSuccess {
+		surface.Destroy()
+		return nil, status
+	}
+	return surface, nil
+}
+
+// Crop returns a new image surface containing a copy of the rectangle x, y, w, h of this surface.
+func (s *Surface) Crop(x, y, w, h float64) (*Surface, error) {
+	surface, err := s.newImageSurfaceLike(int(w), int(h))
+	if err != nil {
+		return nil, err
+	}
+	context := NewContext(surface)
+	defer context.Destroy()
+	context.SetOperator(OperatorSource)
+	context.SetSourceSurface(s, -x, -y)
+	context.Paint()
+	return surface, nil
+}
+
+// Resize returns a new image surface containing a copy of this surface scaled to w x h,
+// sampled with the given filter. Use FilterNearest to keep hard pixel edges, or FilterBest for smooth scaling.
+func (s *Surface) Resize(w, h float64, filter Filter) (*Surface, error) {
+	sw, sh := s.GetWidthF(), s.GetHeightF()
+	if sw <= 0 || sh <= 0 {
+		return nil, errors.New("cannot resize a surface without a size")
+	}
+	surface, err := s.newImageSurfaceLike(int(w), int(h))
+	if err != nil {
+		return nil, err
+	}
+	context := NewContext(surface)
+	defer context.Destroy()
+	context.SetOperator(OperatorSource)
+	context.Scale(float64(int(w))/sw, float64(int(h))/sh)
+	context.SetSourceSurface(s, 0, 0)
+	source := context.GetSource()
+	source.SetFilter(filter)
+	// pad so that edge pixels are not blended with transparency.
+	source.SetExtend(ExtendPad)
+	context.Paint()
+	return surface, nil
+}
+
+// Clone returns a new image surface containing a copy of this surface.
+func (s *Surface) Clone() (*Surface, error) {
+	return s.Crop(0, 0, s.GetWidthF(), s.GetHeightF())
+}
@@ -0,0 +1,52 @@
+// Package cairo wraps the c cairographics library.
+package cairo
+
+// #include <cairo/cairo.h>
+// #include <stdlib.h>
+import "C"
+
+import (
+	"runtime/cgo"
+	"sync"
+	"unsafe"
+)
+
+// user data keys are compared by address in cairo, so each string key maps to one allocated key.
+var (
+	userDataKeysMutex sync.Mutex
+	userDataKeys      = map[string]*C.cairo_user_data_key_t{}
+)
+
+// userDataKey returns the cairo user data key for a string key.
+func userDataKey(key string) *C.cairo_user_data_key_t {
+	userDataKeysMutex.Lock()
+	defer userDataKeysMutex.Unlock()
+	k, ok := userDataKeys[key]
+	if !ok {
+		k = (*C.cairo_user_data_key_t)(C.malloc(C.sizeof_cairo_user_data_key_t))
+		userDataKeys[key] = k
+	}
+	return k
+}
+
+// newHandleData stores a handle to a Go value in c memory, so it can be held by cairo as user data.
+// It is released by blcairoDeleteHandle.
+func newHandleData(value any) unsafe.Pointer {
+	data := C.malloc(C.size_t(unsafe.Sizeof(cgo.Handle(0))))
+	*(*cgo.Handle)(data) = cgo.NewHandle(value)
+	return data
+}
+
+// handleDataValue returns the Go value stored by newHandleData, or nil.
+func handleDataValue(data unsafe.Pointer) any {
+	if data == nil {
+		return nil
+	}
+	return (*(*cgo.Handle)(data)).Value()
+}
+
+//export blcairoDeleteHandle
+func blcairoDeleteHandle(data unsafe.Pointer) {
+	(*(*cgo.Handle)(data)).Delete()
+	C.free(data)
+}